@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"os"
 	"reflect"
 	"sort"
 	"strconv"
@@ -29,16 +28,6 @@ func SortKeys(keys []string) []string {
 	return keys
 }
 
-func GetOSenv(env string) string {
-	keyval := strings.SplitN(env, "=", 2)
-	if keyval[1][:1] == "$" {
-		keyval[1] = os.Getenv(keyval[1][1:])
-		return strings.Join(keyval, "=")
-	}
-
-	return env
-}
-
 func OutputFormatedLine(v *gocui.View, i interface{}) {
 
 	elem := reflect.ValueOf(i).Elem()
@@ -95,3 +84,75 @@ func OutputFormatedHeader(v *gocui.View, i interface{}) {
 
 	OutputFormatedLine(v, i)
 }
+
+// SplitArgs splits the command line into arguments like a shell.
+// The arguments can be quoted with single or double quotes and
+// a backslash escapes the next character.
+func SplitArgs(line string) ([]string, error) {
+	var (
+		args   []string
+		arg    bytes.Buffer
+		inArg  bool
+		quote  rune
+		escape bool
+	)
+
+	for _, r := range line {
+		switch {
+		case escape:
+			arg.WriteRune(r)
+			escape = false
+		case r == '\\' && quote != '\'':
+			escape = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote: %s", line)
+	}
+
+	if escape {
+		return nil, fmt.Errorf("unterminated escape: %s", line)
+	}
+
+	if inArg {
+		args = append(args, arg.String())
+	}
+
+	return args, nil
+}
+
+// JoinArgs joins the arguments quoting them so that SplitArgs can split them again.
+func JoinArgs(args []string) string {
+	quoted := make([]string, len(args))
+
+	for i, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t'\"\\") {
+			quoted[i] = arg
+			continue
+		}
+
+		quoted[i] = "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+	}
+
+	return strings.Join(quoted, " ")
+}
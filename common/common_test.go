@@ -0,0 +1,86 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"", nil},
+		{"  ", nil},
+		{"ls -la /tmp", []string{"ls", "-la", "/tmp"}},
+		{"a\t b", []string{"a", "b"}},
+		{`sh -c "echo hello"`, []string{"sh", "-c", "echo hello"}},
+		{`echo 'a "b" c'`, []string{"echo", `a "b" c`}},
+		{`echo "it's"`, []string{"echo", "it's"}},
+		{`echo a\ b`, []string{"echo", "a b"}},
+		{`echo 'a\b'`, []string{"echo", `a\b`}},
+		{`echo "a\"b"`, []string{"echo", `a"b`}},
+		{`echo '' ""`, []string{"echo", "", ""}},
+		{`KEY=a' 'b`, []string{"KEY=a b"}},
+	}
+
+	for _, tt := range tests {
+		got, err := SplitArgs(tt.line)
+		if err != nil {
+			t.Errorf("SplitArgs(%q) returned error: %s", tt.line, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitArgs(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestSplitArgsError(t *testing.T) {
+	for _, line := range []string{`echo "a`, `echo 'a`, `echo a\`} {
+		if _, err := SplitArgs(line); err == nil {
+			t.Errorf("SplitArgs(%q) returned no error", line)
+		}
+	}
+}
+
+func TestJoinArgs(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"ls", "-la"}, "ls -la"},
+		{[]string{"sh", "-c", "echo hello"}, "sh -c 'echo hello'"},
+		{[]string{""}, "''"},
+		{[]string{"it's"}, `'it'\''s'`},
+	}
+
+	for _, tt := range tests {
+		if got := JoinArgs(tt.args); got != tt.want {
+			t.Errorf("JoinArgs(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestJoinArgsRoundTrip(t *testing.T) {
+	tests := [][]string{
+		{"ls", "-la"},
+		{"sh", "-c", "echo 'hello' \"world\""},
+		{"a\\b", "tab\there", ""},
+		{"KEY=hello, world", "PATH=/usr/bin:/bin"},
+	}
+
+	for _, args := range tests {
+		got, err := SplitArgs(JoinArgs(args))
+		if err != nil {
+			t.Errorf("SplitArgs(JoinArgs(%q)) returned error: %s", args, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, args) {
+			t.Errorf("SplitArgs(JoinArgs(%q)) = %q", args, got)
+		}
+	}
+}
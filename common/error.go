@@ -7,4 +7,8 @@ var (
 	NoImage     = errors.New("No image")
 	NoVolume    = errors.New("No volume")
 	NoNetwork   = errors.New("No network")
+	NoService   = errors.New("No service")
+	NoNode      = errors.New("No node")
+	NoSecret    = errors.New("No secret")
+	NoConfig    = errors.New("No config")
 )
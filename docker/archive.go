@@ -0,0 +1,487 @@
+package docker
+
+import (
+	"archive/tar"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// ErrNotDirectory is returned when the path in the container is not a directory.
+var ErrNotDirectory = errors.New("not a directory")
+
+// download streams the tar archive of the path in the container to f.
+// The download is stopped when f returns before reading all of the archive.
+func (d *Docker) download(id, p string, f func(tr *tar.Reader) error) error {
+	r, w := io.Pipe()
+
+	errCh := make(chan error, 1)
+	go func() {
+		err := d.DownloadFromContainer(id, docker.DownloadFromContainerOptions{
+			Path:         p,
+			OutputStream: w,
+		})
+		w.CloseWithError(err)
+		errCh <- err
+	}()
+
+	err := f(tar.NewReader(r))
+	r.CloseWithError(io.ErrClosedPipe)
+
+	if derr := <-errCh; derr != nil && derr != io.ErrClosedPipe && err == nil {
+		return derr
+	}
+
+	return err
+}
+
+// archiveName returns the name of the entry without the leading "./" and "/".
+func archiveName(name string) string {
+	for {
+		switch {
+		case strings.HasPrefix(name, "./"):
+			name = name[2:]
+		case strings.HasPrefix(name, "/"):
+			name = name[1:]
+		default:
+			return strings.TrimSuffix(name, "/")
+		}
+	}
+}
+
+// pathStat is the file info of the path in the container returned by the archive API.
+type pathStat struct {
+	PathName   string      `json:"name"`
+	PathSize   int64       `json:"size"`
+	PathMode   os.FileMode `json:"mode"`
+	Mtime      time.Time   `json:"mtime"`
+	LinkTarget string      `json:"linkTarget"`
+}
+
+func (s *pathStat) Name() string       { return s.PathName }
+func (s *pathStat) Size() int64        { return s.PathSize }
+func (s *pathStat) Mode() os.FileMode  { return s.PathMode }
+func (s *pathStat) ModTime() time.Time { return s.Mtime }
+func (s *pathStat) IsDir() bool        { return s.PathMode.IsDir() }
+func (s *pathStat) Sys() interface{}   { return nil }
+
+// statContainerPath returns the file info of the path in the container without downloading it.
+// The symbolic link is not followed unless the path ends with a slash.
+func (d *Docker) statContainerPath(id, p string) (*pathStat, error) {
+	header, _, err := d.do(http.MethodHead, "/containers/"+id+"/archive?path="+url.QueryEscape(p), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("can not stat %s: %s", p, err)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(header.Get("X-Docker-Container-Path-Stat"))
+	if err != nil {
+		return nil, err
+	}
+
+	stat := &pathStat{}
+	if err := json.Unmarshal(data, stat); err != nil {
+		return nil, err
+	}
+
+	return stat, nil
+}
+
+// ListContainerDir returns the entries of the directory in the container.
+// The Name of the entries is the base name, and the directories come first.
+// The names are listed with ls in the running container and the entries are stat one by one,
+// because the archive of the directory contains all of the subdirectories.
+// The archive is used only when ls is not available, such as in the stopped containers.
+func (d *Docker) ListContainerDir(id, dir string) ([]*tar.Header, error) {
+	dir = path.Clean("/" + dir)
+
+	p := dir
+	if dir != "/" {
+		// the trailing slash follows the symbolic link to the directory
+		p += "/"
+	}
+
+	info, err := d.statContainerPath(id, p)
+	if err != nil {
+		if dir != "/" {
+			if info, serr := d.statContainerPath(id, dir); serr == nil && !info.IsDir() {
+				return nil, ErrNotDirectory
+			}
+		}
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, ErrNotDirectory
+	}
+
+	entries, err := d.listDir(id, dir, p)
+	if err != nil {
+		if entries, err = d.listArchiveDir(id, dir, p); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		idir := entries[i].Typeflag == tar.TypeDir
+		jdir := entries[j].Typeflag == tar.TypeDir
+		if idir != jdir {
+			return idir
+		}
+
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries, nil
+}
+
+// listDir lists the names with ls in the container and stats them.
+// The entries removed while listing are skipped.
+func (d *Docker) listDir(id, dir, p string) ([]*tar.Header, error) {
+	out, err := d.execOutput(id, []string{"ls", "-1A", "--", p})
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, name := range strings.Split(string(out), "\n") {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+
+	headers := make([]*tar.Header, len(names))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 8)
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			info, err := d.statContainerPath(id, path.Join(dir, name))
+			if err != nil {
+				return
+			}
+
+			if header, err := tar.FileInfoHeader(info, info.LinkTarget); err == nil {
+				headers[i] = header
+			}
+		}(i, name)
+	}
+	wg.Wait()
+
+	var entries []*tar.Header
+	for _, header := range headers {
+		if header != nil {
+			entries = append(entries, header)
+		}
+	}
+
+	return entries, nil
+}
+
+// listArchiveDir lists the first level entries of the archive of the directory.
+// It works on stopped containers too, but downloads all of the subdirectories.
+func (d *Docker) listArchiveDir(id, dir, p string) ([]*tar.Header, error) {
+	var entries []*tar.Header
+
+	err := d.download(id, p, func(tr *tar.Reader) error {
+		first := true
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			name := archiveName(header.Name)
+
+			if dir != "/" {
+				// the first element is the name of the directory itself
+				if first && header.Typeflag != tar.TypeDir {
+					return ErrNotDirectory
+				}
+
+				if i := strings.Index(name, "/"); i != -1 {
+					name = name[i+1:]
+				} else {
+					name = ""
+				}
+			}
+			first = false
+
+			if name == "" || name == "." || strings.Contains(name, "/") {
+				continue
+			}
+
+			header.Name = name
+			entries = append(entries, header)
+		}
+	})
+
+	return entries, err
+}
+
+// ReadContainerFile reads the file in the container up to limit bytes.
+// It reports whether the file is larger than limit.
+func (d *Docker) ReadContainerFile(id, file string, limit int64) ([]byte, bool, error) {
+	var (
+		data      []byte
+		truncated bool
+	)
+
+	err := d.download(id, path.Clean("/"+file), func(tr *tar.Reader) error {
+		header, err := tr.Next()
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			return fmt.Errorf("%s is a directory", file)
+		case tar.TypeSymlink:
+			return fmt.Errorf("%s is a symbolic link to %s", file, header.Linkname)
+		case tar.TypeReg, tar.TypeRegA:
+		default:
+			return fmt.Errorf("%s is not a regular file", file)
+		}
+
+		data, err = ioutil.ReadAll(io.LimitReader(tr, limit))
+		truncated = header.Size > limit
+
+		return err
+	})
+
+	return data, truncated, err
+}
+
+// CopyFromContainer copies the file or directory in the container to the host like docker cp.
+// If dst is an existing directory, src is copied into it, otherwise src is copied as dst.
+func (d *Docker) CopyFromContainer(id, src, dst string) error {
+	src = path.Clean("/" + src)
+	if src == "/" {
+		return errors.New("can not copy the root directory, please export the container")
+	}
+
+	base := dst
+	rename := ""
+
+	if info, err := os.Stat(dst); err != nil || !info.IsDir() {
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		base = filepath.Dir(dst)
+		rename = filepath.Base(dst)
+	}
+
+	return d.download(id, src, func(tr *tar.Reader) error {
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			name := archiveName(header.Name)
+			if rename != "" {
+				name = renameRoot(name, rename)
+			}
+
+			if err := extract(tr, header, base, name); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// renameRoot replaces the first element of the name.
+func renameRoot(name, root string) string {
+	if i := strings.Index(name, "/"); i != -1 {
+		return root + name[i:]
+	}
+
+	return root
+}
+
+// extract writes the entry of the archive to the base directory.
+func extract(tr *tar.Reader, header *tar.Header, base, name string) error {
+	target, err := joinBase(base, name)
+	if err != nil {
+		return err
+	}
+
+	// the symbolic link in the archive must not redirect the entry outside of base
+	if info, err := os.Lstat(target); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if err := os.Remove(target); err != nil {
+			return err
+		}
+	}
+
+	mode := header.FileInfo().Mode()
+
+	switch header.Typeflag {
+	case tar.TypeDir:
+		if err := os.MkdirAll(target, mode.Perm()|0700); err != nil {
+			return err
+		}
+	case tar.TypeReg, tar.TypeRegA:
+		file, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode.Perm())
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(file, tr)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	case tar.TypeSymlink:
+		os.Remove(target)
+		return os.Symlink(header.Linkname, target)
+	case tar.TypeLink:
+		link, err := joinBase(base, archiveName(header.Linkname))
+		if err != nil {
+			return err
+		}
+
+		os.Remove(target)
+		return os.Link(link, target)
+	default:
+		// devices and fifos are not copied
+		return nil
+	}
+
+	return os.Chtimes(target, header.ModTime, header.ModTime)
+}
+
+// joinBase joins the name of the entry to base and prevents to write outside of base.
+// The parent directories under base must not be symbolic links,
+// otherwise the archive could write through the link it extracted before.
+func joinBase(base, name string) (string, error) {
+	target := filepath.Join(base, filepath.FromSlash(name))
+
+	rel, err := filepath.Rel(base, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid path in the archive: %s", name)
+	}
+
+	parent := base
+	elems := strings.Split(rel, string(filepath.Separator))
+	for _, elem := range elems[:len(elems)-1] {
+		parent = filepath.Join(parent, elem)
+
+		info, err := os.Lstat(parent)
+		if os.IsNotExist(err) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("invalid path in the archive: %s is under the symbolic link %s", name, parent)
+		}
+	}
+
+	return target, nil
+}
+
+// CopyToContainer copies the file or directory on the host into the directory in the container.
+func (d *Docker) CopyToContainer(id, src, dst string) error {
+	if _, err := os.Lstat(src); err != nil {
+		return err
+	}
+
+	r, w := io.Pipe()
+
+	go func() {
+		w.CloseWithError(archive(w, src))
+	}()
+
+	err := d.UploadToContainer(id, docker.UploadToContainerOptions{
+		InputStream: r,
+		Path:        path.Clean("/" + dst),
+	})
+	r.Close()
+
+	return err
+}
+
+// archive writes the tar archive of src to w.
+// The root of the archive is the base name of src.
+func archive(w io.Writer, src string) error {
+	tw := tar.NewWriter(w)
+
+	src = filepath.Clean(src)
+	root := filepath.Dir(src)
+
+	err := filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+
+		name, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+
+		header.Name = filepath.ToSlash(name)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(tw, file)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
@@ -0,0 +1,144 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// extractAll extracts the entries to base like CopyFromContainer.
+func extractAll(t *testing.T, headers []*tar.Header, base string) error {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, header := range headers {
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if header.Size > 0 {
+			tw.Write(bytes.Repeat([]byte("x"), int(header.Size)))
+		}
+	}
+	tw.Close()
+
+	tr := tar.NewReader(&buf)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := extract(tr, header, base, archiveName(header.Name)); err != nil {
+			return err
+		}
+	}
+}
+
+func TestExtract(t *testing.T) {
+	base, err := ioutil.TempDir("", "docui")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(base)
+
+	err = extractAll(t, []*tar.Header{
+		{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "dir/file", Typeflag: tar.TypeReg, Mode: 0644, Size: 3},
+		{Name: "dir/link", Typeflag: tar.TypeSymlink, Linkname: "file"},
+	}, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(base, "dir", "link"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "xxx" {
+		t.Errorf("dir/link = %q, want %q", data, "xxx")
+	}
+}
+
+func TestExtractOutsideOfBase(t *testing.T) {
+	tests := map[string][]*tar.Header{
+		"parent": {
+			{Name: "../file", Typeflag: tar.TypeReg, Mode: 0644, Size: 1},
+		},
+		"directory link": {
+			{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "OUTSIDE"},
+			{Name: "link/file", Typeflag: tar.TypeReg, Mode: 0644, Size: 1},
+		},
+	}
+
+	for name, headers := range tests {
+		dir, err := ioutil.TempDir("", "docui")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		base := filepath.Join(dir, "base")
+		outside := filepath.Join(dir, "outside")
+		for _, d := range []string{base, outside} {
+			if err := os.Mkdir(d, 0755); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for _, header := range headers {
+			if header.Linkname == "OUTSIDE" {
+				header.Linkname = outside
+			}
+		}
+
+		if err := extractAll(t, headers, base); err == nil {
+			t.Errorf("%s: extract returned no error", name)
+		}
+
+		for _, p := range []string{filepath.Join(dir, "file"), filepath.Join(outside, "file")} {
+			if _, err := os.Lstat(p); err == nil {
+				t.Errorf("%s: %s is written", name, p)
+			}
+		}
+	}
+}
+
+func TestExtractOverSymlink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "docui")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	base := filepath.Join(dir, "base")
+	outside := filepath.Join(dir, "outside")
+	if err := os.Mkdir(base, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	err = extractAll(t, []*tar.Header{
+		{Name: "file", Typeflag: tar.TypeSymlink, Linkname: outside},
+		{Name: "file", Typeflag: tar.TypeReg, Mode: 0644, Size: 1},
+	}, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Lstat(outside); err == nil {
+		t.Errorf("%s is written through the symbolic link", outside)
+	}
+
+	info, err := os.Lstat(filepath.Join(base, "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.Mode().IsRegular() {
+		t.Errorf("file is %s, want a regular file", info.Mode())
+	}
+}
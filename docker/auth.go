@@ -0,0 +1,436 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+const (
+	// DefaultRegistry is the server address of Docker Hub used by docker cli.
+	DefaultRegistry = "https://index.docker.io/v1/"
+	credsNotFound   = "credentials not found in native keychain"
+	tokenUsername   = "<token>"
+)
+
+// Registry is a registry which has credentials in the docker cli config file.
+type Registry struct {
+	ServerAddress string
+	Username      string
+	Helper        string
+}
+
+type authEntry struct {
+	Auth          string `json:"auth,omitempty"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+}
+
+type authConfig struct {
+	Auths       map[string]authEntry `json:"auths"`
+	CredsStore  string               `json:"credsStore"`
+	CredHelpers map[string]string    `json:"credHelpers"`
+}
+
+type helperCredentials struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+func configPath() string {
+	return filepath.Join(ConfigDir(), "config.json")
+}
+
+func loadAuthConfig() (*authConfig, error) {
+	config := &authConfig{}
+
+	b, err := ioutil.ReadFile(configPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, config); err != nil {
+		return nil, fmt.Errorf("%s: %s", configPath(), err)
+	}
+
+	return config, nil
+}
+
+// RegistryHost returns the registry host of the image like docker cli does.
+func RegistryHost(image string) string {
+	i := strings.Index(image, "/")
+	if i == -1 {
+		return DefaultRegistry
+	}
+
+	host := image[:i]
+	if !strings.ContainsAny(host, ".:") && host != "localhost" {
+		return DefaultRegistry
+	}
+
+	if host == "index.docker.io" || host == "docker.io" {
+		return DefaultRegistry
+	}
+
+	return host
+}
+
+// normalizeRegistry strips the scheme and the path from the server address.
+func normalizeRegistry(address string) string {
+	host := address
+	if i := strings.Index(host, "://"); i != -1 {
+		host = host[i+3:]
+	}
+	host = strings.SplitN(host, "/", 2)[0]
+
+	if host == "index.docker.io" || host == "docker.io" || host == "registry-1.docker.io" {
+		return DefaultRegistry
+	}
+
+	return host
+}
+
+// helper returns the credential helper name for the registry.
+func (c *authConfig) helper(registry string) string {
+	for address, helper := range c.CredHelpers {
+		if normalizeRegistry(address) == registry {
+			return helper
+		}
+	}
+
+	return c.CredsStore
+}
+
+// entry returns the entry of auths for the registry.
+func (c *authConfig) entry(registry string) (authEntry, bool) {
+	for address, entry := range c.Auths {
+		if normalizeRegistry(address) == registry {
+			return entry, true
+		}
+	}
+
+	return authEntry{}, false
+}
+
+func runCredentialsHelper(helper, action string, input []byte) ([]byte, error) {
+	cmd := exec.Command("docker-credential-"+helper, action)
+	cmd.Stdin = bytes.NewReader(input)
+
+	out, err := cmd.Output()
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if msg == "" {
+			return nil, fmt.Errorf("docker-credential-%s: %s", helper, err)
+		}
+		return nil, errors.New(msg)
+	}
+
+	return out, nil
+}
+
+func credentialsFromHelper(helper, registry string) (docker.AuthConfiguration, error) {
+	auth := docker.AuthConfiguration{ServerAddress: registry}
+
+	out, err := runCredentialsHelper(helper, "get", []byte(registry))
+	if err != nil {
+		if err.Error() == credsNotFound {
+			return auth, nil
+		}
+		return auth, err
+	}
+
+	var creds helperCredentials
+	if err := json.Unmarshal(out, &creds); err != nil {
+		return auth, err
+	}
+
+	if creds.Username == tokenUsername {
+		auth.IdentityToken = creds.Secret
+	} else {
+		auth.Username = creds.Username
+		auth.Password = creds.Secret
+	}
+
+	return auth, nil
+}
+
+func credentialsFromEntry(registry string, entry authEntry) (docker.AuthConfiguration, error) {
+	auth := docker.AuthConfiguration{
+		ServerAddress: registry,
+		IdentityToken: entry.IdentityToken,
+	}
+
+	if entry.Auth == "" {
+		return auth, nil
+	}
+
+	b, err := base64.StdEncoding.DecodeString(entry.Auth)
+	if err != nil {
+		return auth, err
+	}
+
+	userpass := strings.SplitN(string(b), ":", 2)
+	if len(userpass) != 2 {
+		return auth, fmt.Errorf("invalid auth configuration for %s", registry)
+	}
+
+	auth.Username = userpass[0]
+	auth.Password = userpass[1]
+
+	return auth, nil
+}
+
+// AuthConfig returns the credentials for the registry stored by docker cli.
+// If there are no credentials, the empty configuration is returned.
+func AuthConfig(registry string) (docker.AuthConfiguration, error) {
+	registry = normalizeRegistry(registry)
+
+	config, err := loadAuthConfig()
+	if err != nil {
+		return docker.AuthConfiguration{}, err
+	}
+
+	// like docker cli, errors of the credential helper are ignored
+	// so that public images can be pulled without the helper.
+	if helper := config.helper(registry); helper != "" {
+		if auth, err := credentialsFromHelper(helper, registry); err == nil {
+			return auth, nil
+		}
+	}
+
+	if entry, ok := config.entry(registry); ok {
+		return credentialsFromEntry(registry, entry)
+	}
+
+	return docker.AuthConfiguration{ServerAddress: registry}, nil
+}
+
+// AuthConfigForImage returns the credentials for the registry of the image.
+func AuthConfigForImage(image string) (docker.AuthConfiguration, error) {
+	return AuthConfig(RegistryHost(image))
+}
+
+// AllAuthConfigs returns the credentials for all registries to pull the base images in a build.
+func AllAuthConfigs() (docker.AuthConfigurations, error) {
+	auths := docker.AuthConfigurations{
+		Configs: make(map[string]docker.AuthConfiguration),
+	}
+
+	registries, err := Registries()
+	if err != nil {
+		return auths, err
+	}
+
+	for _, registry := range registries {
+		auth, err := AuthConfig(registry.ServerAddress)
+		if err != nil {
+			return auths, err
+		}
+
+		auths.Configs[registry.ServerAddress] = auth
+	}
+
+	return auths, nil
+}
+
+// Registries returns the registries which have credentials.
+func Registries() ([]*Registry, error) {
+	config, err := loadAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]*Registry)
+	for address, entry := range config.Auths {
+		registry := &Registry{
+			ServerAddress: normalizeRegistry(address),
+			Helper:        config.helper(normalizeRegistry(address)),
+		}
+
+		if auth, err := credentialsFromEntry(registry.ServerAddress, entry); err == nil {
+			registry.Username = auth.Username
+		}
+
+		found[registry.ServerAddress] = registry
+	}
+
+	// the registries of credHelpers may not have the entry of auths
+	for address, helper := range config.CredHelpers {
+		address = normalizeRegistry(address)
+		if _, ok := found[address]; !ok {
+			found[address] = &Registry{
+				ServerAddress: address,
+				Helper:        helper,
+			}
+		}
+	}
+
+	var registries []*Registry
+	for _, registry := range found {
+		registries = append(registries, registry)
+	}
+
+	sort.Slice(registries, func(i, j int) bool {
+		return registries[i].ServerAddress < registries[j].ServerAddress
+	})
+
+	return registries, nil
+}
+
+// updateAuths rewrites auths of the config file keeping the other settings.
+func updateAuths(f func(auths map[string]json.RawMessage) error) error {
+	path := configPath()
+	config := map[string]json.RawMessage{}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, &config); err != nil {
+			return fmt.Errorf("%s: %s", path, err)
+		}
+	}
+
+	auths := map[string]json.RawMessage{}
+	if raw, ok := config["auths"]; ok {
+		if err := json.Unmarshal(raw, &auths); err != nil {
+			return fmt.Errorf("%s: %s", path, err)
+		}
+	}
+
+	if err := f(auths); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(auths)
+	if err != nil {
+		return err
+	}
+	config["auths"] = raw
+
+	b, err = json.MarshalIndent(config, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+// Login checks the credentials with the docker daemon and stores them like `docker login`.
+func (d *Docker) Login(registry, username, password string) error {
+	auth := docker.AuthConfiguration{
+		ServerAddress: normalizeRegistry(registry),
+		Username:      username,
+		Password:      password,
+	}
+
+	status, err := d.AuthCheck(&auth)
+	if err != nil {
+		return err
+	}
+
+	if status.IdentityToken != "" {
+		auth.Password = ""
+		auth.IdentityToken = status.IdentityToken
+	}
+
+	config, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	entry := authEntry{}
+
+	if helper := config.helper(auth.ServerAddress); helper != "" {
+		creds := helperCredentials{
+			ServerURL: auth.ServerAddress,
+			Username:  auth.Username,
+			Secret:    auth.Password,
+		}
+
+		if auth.IdentityToken != "" {
+			creds.Username = tokenUsername
+			creds.Secret = auth.IdentityToken
+		}
+
+		b, err := json.Marshal(creds)
+		if err != nil {
+			return err
+		}
+
+		if _, err := runCredentialsHelper(helper, "store", b); err != nil {
+			return err
+		}
+	} else {
+		entry.Auth = base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password))
+		entry.IdentityToken = auth.IdentityToken
+	}
+
+	return updateAuths(func(auths map[string]json.RawMessage) error {
+		for address := range auths {
+			if normalizeRegistry(address) == auth.ServerAddress {
+				delete(auths, address)
+			}
+		}
+
+		b, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+
+		auths[auth.ServerAddress] = b
+
+		return nil
+	})
+}
+
+// Logout removes the credentials of the registry like `docker logout`.
+func Logout(registry string) error {
+	registry = normalizeRegistry(registry)
+
+	config, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	if helper := config.helper(registry); helper != "" {
+		if _, err := runCredentialsHelper(helper, "erase", []byte(registry)); err != nil && err.Error() != credsNotFound {
+			return err
+		}
+	}
+
+	return updateAuths(func(auths map[string]json.RawMessage) error {
+		found := false
+		for address := range auths {
+			if normalizeRegistry(address) == registry {
+				delete(auths, address)
+				found = true
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("not logged in to %s", registry)
+		}
+
+		return nil
+	})
+}
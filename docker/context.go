@@ -0,0 +1,173 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	DefaultContext = "default"
+)
+
+// Context is a docker cli context created by `docker context create`.
+type Context struct {
+	Name        string
+	Description string
+	Config      *ClientConfig
+}
+
+type contextMeta struct {
+	Name     string
+	Metadata struct {
+		Description string
+	}
+	Endpoints map[string]struct {
+		Host          string
+		SkipTLSVerify bool
+	}
+}
+
+type cliConfig struct {
+	CurrentContext string `json:"currentContext"`
+}
+
+// ConfigDir returns the docker cli config directory.
+func ConfigDir() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir
+	}
+
+	return filepath.Join(os.Getenv("HOME"), ".docker")
+}
+
+// CurrentContext returns the context name selected by DOCKER_CONTEXT or `docker context use`.
+func CurrentContext() string {
+	if name := os.Getenv("DOCKER_CONTEXT"); name != "" {
+		return name
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(ConfigDir(), "config.json"))
+	if err != nil {
+		return DefaultContext
+	}
+
+	var config cliConfig
+	if err := json.Unmarshal(b, &config); err != nil || config.CurrentContext == "" {
+		return DefaultContext
+	}
+
+	return config.CurrentContext
+}
+
+// Contexts returns the default context made from config and the contexts of docker cli.
+// The contexts which cannot be read are skipped not to hide the others, and their errors are returned as warnings.
+func Contexts(config *ClientConfig) ([]*Context, []error, error) {
+	contexts := []*Context{
+		&Context{
+			Name:        DefaultContext,
+			Description: "Current DOCKER_HOST based configuration",
+			Config:      config,
+		},
+	}
+
+	dirs, err := ioutil.ReadDir(filepath.Join(ConfigDir(), "contexts", "meta"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return contexts, nil, nil
+		}
+		return contexts, nil, err
+	}
+
+	var others []*Context
+	var warnings []error
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+
+		c, err := loadContext(dir.Name())
+		if err != nil {
+			warnings = append(warnings, err)
+			continue
+		}
+
+		others = append(others, c)
+	}
+
+	sort.Slice(others, func(i, j int) bool {
+		return others[i].Name < others[j].Name
+	})
+
+	return append(contexts, others...), warnings, nil
+}
+
+// FindContext returns the context named name.
+func FindContext(name string, config *ClientConfig) (*Context, error) {
+	contexts, warnings, err := Contexts(config)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range contexts {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+
+	// the context may be the one which cannot be read
+	if len(warnings) > 0 {
+		return nil, fmt.Errorf("context %q does not exist (%s)", name, JoinErrors(warnings))
+	}
+
+	return nil, fmt.Errorf("context %q does not exist", name)
+}
+
+// JoinErrors returns the messages of the errors separated by comma.
+func JoinErrors(errs []error) string {
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+// loadContext reads contexts/meta/<id>/meta.json and contexts/tls/<id>/docker.
+func loadContext(id string) (*Context, error) {
+	path := filepath.Join(ConfigDir(), "contexts", "meta", id, "meta.json")
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var meta contextMeta
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	endpoint, ok := meta.Endpoints["docker"]
+	if !ok {
+		return nil, fmt.Errorf("context %q has no docker endpoint", meta.Name)
+	}
+
+	config := &ClientConfig{
+		Endpoint: endpoint.Host,
+		CertPath: filepath.Join(ConfigDir(), "contexts", "tls", id, "docker"),
+	}
+
+	if _, err := os.Stat(config.CertPath); err == nil {
+		config.TLS = true
+		config.TLSVerify = !endpoint.SkipTLSVerify
+	}
+
+	return &Context{
+		Name:        meta.Name,
+		Description: meta.Metadata.Description,
+		Config:      config,
+	}, nil
+}
@@ -1,30 +1,106 @@
 package docker
 
 import (
-	"errors"
 	"fmt"
+	"io/ioutil"
+	"net"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	docker "github.com/fsouza/go-dockerclient"
-	"github.com/skanehira/docui/common"
 )
 
 const (
-	endpoint = "unix:///var/run/docker.sock"
+	defaultEndpoint = "unix:///var/run/docker.sock"
 )
 
 type Docker struct {
 	*docker.Client
 }
 
-func NewDocker() *Docker {
-	client, err := docker.NewClient(endpoint)
+// ClientConfig is the way to connect to the docker daemon.
+type ClientConfig struct {
+	Endpoint  string
+	CertPath  string
+	TLS       bool
+	TLSVerify bool
+}
+
+// NewClientConfigFromEnv reads DOCKER_HOST, DOCKER_TLS_VERIFY and DOCKER_CERT_PATH
+// like the docker cli does.
+func NewClientConfigFromEnv() *ClientConfig {
+	config := &ClientConfig{
+		Endpoint:  os.Getenv("DOCKER_HOST"),
+		CertPath:  os.Getenv("DOCKER_CERT_PATH"),
+		TLSVerify: os.Getenv("DOCKER_TLS_VERIFY") != "",
+	}
+
+	if config.Endpoint == "" {
+		config.Endpoint = defaultEndpoint
+	}
+
+	if config.CertPath == "" {
+		config.CertPath = filepath.Join(os.Getenv("HOME"), ".docker")
+	}
+
+	return config
+}
+
+// UseTLS reports whether the connection needs TLS.
+func (c *ClientConfig) UseTLS() bool {
+	return c.TLS || c.TLSVerify || strings.HasPrefix(c.Endpoint, "https://")
+}
+
+func NewDocker(config *ClientConfig) (*Docker, error) {
+	if !config.UseTLS() {
+		client, err := docker.NewClient(config.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Docker{client}, nil
+	}
+
+	// the client certificate is optional when the daemon does not verify clients
+	cert, err := readCert(config.CertPath, "cert.pem")
+	if err != nil {
+		return nil, err
+	}
+	key, err := readCert(config.CertPath, "key.pem")
+	if err != nil {
+		return nil, err
+	}
+	if cert == nil || key == nil {
+		cert, key = nil, nil
+	}
+
+	// without ca the server certificate is not verified
+	var ca []byte
+	if config.TLSVerify {
+		ca, err = ioutil.ReadFile(filepath.Join(config.CertPath, "ca.pem"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := docker.NewTLSClientFromBytes(config.Endpoint, cert, key, ca)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	return &Docker{client}
+	return &Docker{client}, nil
+}
+
+// readCert returns the content of the file in the cert path, or nil if it does not exist.
+func readCert(path, name string) ([]byte, error) {
+	b, err := ioutil.ReadFile(filepath.Join(path, name))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	return b, err
 }
 
 func (d *Docker) Images(options docker.ListImagesOptions) []docker.APIImages {
@@ -54,6 +130,36 @@ func (d *Docker) Networks() []docker.Network {
 	return net
 }
 
+// NetworkContainers returns the names of the running containers connected to each network by the network ID.
+// The network list of the daemon does not have the containers,
+// so they are collected from the container list instead of inspecting each network.
+func (d *Docker) NetworkContainers() (map[string][]string, error) {
+	containers, err := d.ListContainers(docker.ListContainersOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string][]string)
+	for _, c := range containers {
+		if len(c.Names) == 0 {
+			continue
+		}
+
+		for _, network := range c.Networks.Networks {
+			if network.EndpointID == "" {
+				continue
+			}
+			names[network.NetworkID] = append(names[network.NetworkID], strings.TrimPrefix(c.Names[0], "/"))
+		}
+	}
+
+	for _, n := range names {
+		sort.Strings(n)
+	}
+
+	return names, nil
+}
+
 func (d *Docker) CreateContainerWithOptions(options docker.CreateContainerOptions) error {
 	_, err := d.CreateContainer(options)
 	if err != nil {
@@ -63,80 +169,93 @@ func (d *Docker) CreateContainerWithOptions(options docker.CreateContainerOption
 	return nil
 }
 
-func (d *Docker) NewContainerOptions(config map[string]string) (docker.CreateContainerOptions, error) {
-
-	options := docker.CreateContainerOptions{
-		Config:     new(docker.Config),
-		HostConfig: new(docker.HostConfig),
+func (d *Docker) RunContainerWithOptions(options docker.CreateContainerOptions) error {
+	container, err := d.CreateContainer(options)
+	if err != nil {
+		return err
 	}
 
-	if image := config["Image"]; image != "" {
-		options.Config.Image = image
-	} else {
-		return options, fmt.Errorf("no specified image")
+	return d.StartContainerWithID(container.ID)
+}
+
+// RecreateContainerWithOptions replaces the container with the new one created from options.
+// The old container is restored if the new one can not be created or started,
+// or if the new one does not mount its volumes or host paths.
+func (d *Docker) RecreateContainerWithOptions(id string, options docker.CreateContainerOptions, timeout uint) error {
+	old, err := d.InspectContainer(id)
+	if err != nil {
+		return err
 	}
 
-	if name := config["Name"]; name != "" {
-		options.Name = name
+	name := strings.TrimPrefix(old.Name, "/")
+	tmpName := fmt.Sprintf("%s_%s_old", name, old.ID[:12])
+
+	rename := func(name string) error {
+		return d.RenameContainer(docker.RenameContainerOptions{ID: old.ID, Name: name})
 	}
 
-	image, err := d.InspectImage(options.Config.Image)
+	if err := rename(tmpName); err != nil {
+		return err
+	}
 
+	container, err := d.CreateContainer(options)
 	if err != nil {
-		return options, err
+		rename(name)
+		return err
 	}
 
-	options.Config.Env = image.Config.Env
+	created, err := d.InspectContainer(container.ID)
+	if err != nil {
+		d.RemoveContainer(docker.RemoveContainerOptions{ID: container.ID})
+		rename(name)
+		return err
+	}
 
-	if port := config["Port"]; port != "" {
-		if hostPort := config["HostPort"]; hostPort != "" {
-			options.HostConfig.PortBindings = map[docker.Port][]docker.PortBinding{
-				docker.Port(port + "/tcp"): []docker.PortBinding{
-					docker.PortBinding{
-						HostIP:   "0.0.0.0",
-						HostPort: hostPort,
-					},
-				},
-			}
-		}
+	// the data of the old container must not be lost with it
+	if missing := missingMounts(old.Mounts, created.Mounts); len(missing) > 0 {
+		d.RemoveContainer(docker.RemoveContainerOptions{ID: container.ID})
+		rename(name)
+		return fmt.Errorf("%s is not recreated because the new container does not mount %s", name, strings.Join(missing, ", "))
 	}
 
-	if cmd := config["Cmd"]; cmd != "" {
-		cmds := strings.Split(cmd, ",")
-		for _, c := range cmds {
-			options.Config.Cmd = append(options.Config.Cmd, c)
+	if old.State.Running {
+		if err := d.StopContainerWithID(old.ID, timeout); err != nil {
+			d.RemoveContainer(docker.RemoveContainerOptions{ID: container.ID})
+			rename(name)
+			return err
 		}
 	}
 
-	if env := config["Env"]; env != "" {
-		for _, v := range strings.Split(env, ",") {
-			v = common.GetOSenv(v)
-			options.Config.Env = append(options.Config.Env, v)
+	if err := d.StartContainerWithID(container.ID); err != nil {
+		d.RemoveContainer(docker.RemoveContainerOptions{ID: container.ID})
+		rename(name)
+		if old.State.Running {
+			d.StartContainerWithID(old.ID)
 		}
+		return err
 	}
 
-	if hostVolume := config["HostVolume"]; hostVolume != "" {
-		if volume := config["Volume"]; volume != "" {
-			options.HostConfig.Mounts = []docker.HostMount{
-				docker.HostMount{
-					Target: volume,
-					Source: hostVolume,
-					Type:   "bind",
-				},
-			}
+	// the old container has been removed already if it was run with auto remove
+	if err := d.RemoveContainer(docker.RemoveContainerOptions{ID: old.ID}); err != nil {
+		if _, ok := err.(*docker.NoSuchContainer); !ok {
+			return err
 		}
 	}
 
-	options.Config.AttachStdout = true
-	options.Config.AttachStderr = true
+	return nil
+}
 
-	if attach := config["Attach"]; attach == "y" {
-		options.Config.Tty = true
-		options.Config.AttachStdin = true
-		options.Config.OpenStdin = true
+func (d *Docker) NewContainerOptions(config map[string]string) (docker.CreateContainerOptions, error) {
+	if config["Image"] == "" {
+		return docker.CreateContainerOptions{}, fmt.Errorf("no specified image")
 	}
 
-	return options, nil
+	image, err := d.InspectImage(config["Image"])
+	if err != nil {
+		return docker.CreateContainerOptions{}, err
+	}
+
+	return containerOptions(config, image)
 }
 
 func (d *Docker) CommitContainerWithOptions(options docker.CommitContainerOptions) error {
@@ -170,8 +289,51 @@ func (d *Docker) StartContainerWithID(id string) error {
 	return nil
 }
 
-func (d *Docker) StopContainerWithID(id string) error {
-	if err := d.StopContainer(id, 30); err != nil {
+func (d *Docker) StopContainerWithID(id string, timeout uint) error {
+	if err := d.StopContainer(id, timeout); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *Docker) RestartContainerWithID(id string, timeout uint) error {
+	if err := d.RestartContainer(id, timeout); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *Docker) PauseContainerWithID(id string) error {
+	if err := d.PauseContainer(id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *Docker) UnpauseContainerWithID(id string) error {
+	if err := d.UnpauseContainer(id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// KillContainerWithSignal sends the signal like `SIGHUP`, `HUP` or `1` to the container.
+func (d *Docker) KillContainerWithSignal(id, signal string) error {
+	sig, err := ParseSignal(signal)
+	if err != nil {
+		return err
+	}
+
+	options := docker.KillContainerOptions{
+		ID:     id,
+		Signal: sig,
+	}
+
+	if err := d.KillContainer(options); err != nil {
 		return err
 	}
 
@@ -179,47 +341,62 @@ func (d *Docker) StopContainerWithID(id string) error {
 }
 
 func (d *Docker) PullImageWithOptions(options docker.PullImageOptions) error {
-	if err := d.PullImage(options, docker.AuthConfiguration{}); err != nil {
+	auth, err := AuthConfigForImage(options.Repository)
+	if err != nil {
+		return err
+	}
+
+	if err := d.PullImage(options, auth); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (d *Docker) RemoveImageWithName(name string) error {
-	if err := d.RemoveImage(name); err != nil {
+func (d *Docker) PushImageWithOptions(options docker.PushImageOptions) error {
+	auth, err := AuthConfigForImage(options.Name)
+	if err != nil {
 		return err
 	}
 
+	if err := d.PushImage(options, auth); err != nil {
+		return err
+	}
 	return nil
 }
 
-func (d *Docker) RemoveDanglingImages() error {
-	options := docker.ListImagesOptions{
-		Filters: map[string][]string{
-			"dangling": []string{
-				"true", "1",
-			},
-		},
+func (d *Docker) TagImageWithOptions(name string, options docker.TagImageOptions) error {
+	if err := d.TagImage(name, options); err != nil {
+		return err
 	}
 
-	images := d.Images(options)
-	errids := []string{}
+	return nil
+}
 
-	for _, image := range images {
-		if err := d.RemoveImageWithName(image.ID); err != nil {
-			errids = append(errids, image.ID[7:19])
-		}
+func (d *Docker) BuildImageWithOptions(options docker.BuildImageOptions) error {
+	auths, err := AllAuthConfigs()
+	if err != nil {
+		return err
 	}
 
-	if len(errids) > 1 {
-		return errors.New(fmt.Sprintf("can not remove ids\n%s", errids))
+	options.AuthConfigs = auths
+
+	if err := d.BuildImage(options); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *Docker) RemoveImageWithName(name string) error {
+	if err := d.RemoveImage(name); err != nil {
+		return err
 	}
 
 	return nil
 }
 
-func (d *Docker) SaveImageWithOptions(options docker.ExportImageOptions) error {
-	if err := d.ExportImage(options); err != nil {
+func (d *Docker) SaveImagesWithOptions(options docker.ExportImagesOptions) error {
+	if err := d.ExportImages(options); err != nil {
 		return err
 	}
 
@@ -262,7 +439,12 @@ func (d *Docker) ExportContainerWithOptions(options docker.ExportContainerOption
 }
 
 func (d *Docker) SearchImageWithName(name string) ([]docker.APIImageSearch, error) {
-	images, err := d.Client.SearchImages(name)
+	auth, err := AuthConfigForImage(name)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := d.Client.SearchImagesEx(name, auth)
 
 	if err != nil {
 		return images, err
@@ -285,16 +467,6 @@ func (d *Docker) RemoveVolumeWithName(name string) error {
 	return d.RemoveVolume(name)
 }
 
-func (d *Docker) PruneVolumes() error {
-	_, err := d.Client.PruneVolumes(docker.PruneVolumesOptions{})
-
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func (d *Docker) CreateVolumeWithOptions(options docker.CreateVolumeOptions) error {
 	_, err := d.Client.CreateVolume(options)
 	return err
@@ -330,12 +502,82 @@ func (d *Docker) NewCreateVolumeOptions(data map[string]string) docker.CreateVol
 	return options
 }
 
-func (d *Docker) DiskUsage() *docker.DiskUsage {
-	usage, err := d.Client.DiskUsage(docker.DiskUsageOptions{})
+func (d *Docker) CreateNetworkWithOptions(options docker.CreateNetworkOptions) error {
+	_, err := d.Client.CreateNetwork(options)
+	return err
+}
+
+// NewCreateNetworkOptions converts the values of the create network panel to the options.
+func (d *Docker) NewCreateNetworkOptions(data map[string]string) (docker.CreateNetworkOptions, error) {
+	options := docker.CreateNetworkOptions{
+		Name:           data["Name"],
+		Driver:         data["Driver"],
+		Internal:       data["Internal"] == "y",
+		Attachable:     data["Attachable"] == "y",
+		CheckDuplicate: true,
+	}
+
+	labels, err := parseLabels(data["Labels"])
+	if err != nil {
+		return options, err
+	}
+	options.Labels = labels
 
+	driverOpts, err := parseLabels(data["Options"])
 	if err != nil {
-		return nil
+		return options, err
 	}
 
-	return usage
+	options.Options = make(map[string]interface{})
+	for k, v := range driverOpts {
+		options.Options[k] = v
+	}
+
+	ipam, err := parseIPAM(data["Subnet"], data["Gateway"], data["IPRange"])
+	if err != nil {
+		return options, err
+	}
+
+	if ipam != nil {
+		options.IPAM = &docker.IPAMOptions{
+			Driver: "default",
+			Config: []docker.IPAMConfig{*ipam},
+		}
+		options.EnableIPv6 = strings.Contains(ipam.Subnet, ":")
+	}
+
+	return options, nil
+}
+
+// ConnectNetworkWithContainer connects the container to the network with the aliases and the static ip address.
+func (d *Docker) ConnectNetworkWithContainer(network, container string, aliases []string, ip string) error {
+	config := &docker.EndpointConfig{
+		Aliases: aliases,
+	}
+
+	if ip != "" {
+		addr := net.ParseIP(ip)
+		if addr == nil {
+			return fmt.Errorf("invalid ip address: %s", ip)
+		}
+
+		if addr.To4() != nil {
+			config.IPAMConfig = &docker.EndpointIPAMConfig{IPv4Address: ip}
+		} else {
+			config.IPAMConfig = &docker.EndpointIPAMConfig{IPv6Address: ip}
+		}
+	}
+
+	return d.ConnectNetwork(network, docker.NetworkConnectionOptions{
+		Container:      container,
+		EndpointConfig: config,
+	})
+}
+
+// DisconnectNetworkWithContainer disconnects the container from the network.
+func (d *Docker) DisconnectNetworkWithContainer(network, container string, force bool) error {
+	return d.DisconnectNetwork(network, docker.NetworkConnectionOptions{
+		Container: container,
+		Force:     force,
+	})
 }
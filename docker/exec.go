@@ -0,0 +1,124 @@
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// ExecWithTTY runs cmd in the container with a tty attached to the terminal
+// and blocks until the command exits.
+func (d *Docker) ExecWithTTY(container string, cmd []string) error {
+	exec, err := d.CreateExec(docker.CreateExecOptions{
+		Container:    container,
+		Cmd:          cmd,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Tty:          true,
+	})
+	if err != nil {
+		return err
+	}
+
+	// read the input from /dev/tty instead of os.Stdin,
+	// because the tty is closed when the command exits and stops stealing the input.
+	tty, err := os.OpenFile("/dev/tty", os.O_RDONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer tty.Close()
+
+	fd := int(os.Stdin.Fd())
+	state, err := terminal.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer terminal.Restore(fd, state)
+
+	success := make(chan struct{})
+	cw, err := d.StartExecNonBlocking(exec.ID, docker.StartExecOptions{
+		InputStream:  tty,
+		OutputStream: os.Stdout,
+		ErrorStream:  os.Stderr,
+		Tty:          true,
+		RawTerminal:  true,
+		Success:      success,
+	})
+	if err != nil {
+		return err
+	}
+
+	<-success
+	d.resizeExecTTY(exec.ID)
+	success <- struct{}{}
+
+	// propagate the terminal size
+	winch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(winch, syscall.SIGWINCH)
+	defer signal.Stop(winch)
+	defer close(done)
+
+	go func() {
+		for {
+			select {
+			case <-winch:
+				d.resizeExecTTY(exec.ID)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return cw.Wait()
+}
+
+func (d *Docker) resizeExecTTY(id string) {
+	width, height, err := terminal.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return
+	}
+
+	d.ResizeExecTTY(id, height, width)
+}
+
+// execOutput runs cmd in the container and returns the standard output.
+// The standard error is returned as an error when the command fails.
+func (d *Docker) execOutput(container string, cmd []string) ([]byte, error) {
+	exec, err := d.CreateExec(docker.CreateExecOptions{
+		Container:    container,
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = d.StartExec(exec.ID, docker.StartExecOptions{
+		OutputStream: &stdout,
+		ErrorStream:  &stderr,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	inspect, err := d.InspectExec(exec.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if inspect.ExitCode != 0 {
+		return nil, fmt.Errorf("%s: %s", strings.Join(cmd, " "), strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,646 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/skanehira/docui/common"
+)
+
+// parsePorts parses the ports like `docker run -p`.
+// Each port is `[ip:][hostPort:]containerPort[/protocol]` separated by spaces.
+func parsePorts(ports string) (map[docker.Port]struct{}, map[docker.Port][]docker.PortBinding, error) {
+	exposed := make(map[docker.Port]struct{})
+	bindings := make(map[docker.Port][]docker.PortBinding)
+
+	for _, p := range strings.Fields(ports) {
+		proto := "tcp"
+		if i := strings.LastIndex(p, "/"); i != -1 {
+			proto = strings.ToLower(p[i+1:])
+			p = p[:i]
+		}
+
+		if proto != "tcp" && proto != "udp" && proto != "sctp" {
+			return nil, nil, fmt.Errorf("invalid protocol: %s", proto)
+		}
+
+		var ip, hostPort, containerPort string
+
+		parts := strings.Split(p, ":")
+		switch len(parts) {
+		case 1:
+			containerPort = parts[0]
+		case 2:
+			hostPort, containerPort = parts[0], parts[1]
+		case 3:
+			ip, hostPort, containerPort = parts[0], parts[1], parts[2]
+		default:
+			return nil, nil, fmt.Errorf("invalid port: %s", p)
+		}
+
+		if _, err := strconv.ParseUint(containerPort, 10, 16); err != nil {
+			return nil, nil, fmt.Errorf("invalid port: %s", p)
+		}
+
+		if hostPort != "" {
+			if _, err := strconv.ParseUint(hostPort, 10, 16); err != nil {
+				return nil, nil, fmt.Errorf("invalid port: %s", p)
+			}
+		}
+
+		port := docker.Port(containerPort + "/" + proto)
+		exposed[port] = struct{}{}
+		bindings[port] = append(bindings[port], docker.PortBinding{
+			HostIP:   ip,
+			HostPort: hostPort,
+		})
+	}
+
+	return exposed, bindings, nil
+}
+
+// parseEnv parses the environment variables like `docker run -e`.
+// Each variable is `key=value` separated by spaces, and can be quoted like the command.
+// The key without the value takes the value from the environment of docui.
+func parseEnv(env string) ([]string, error) {
+	args, err := common.SplitArgs(env)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "=") {
+			return nil, fmt.Errorf("invalid env: %s", arg)
+		}
+
+		if !strings.Contains(arg, "=") {
+			value, ok := os.LookupEnv(arg)
+			if !ok {
+				continue
+			}
+			arg += "=" + value
+		}
+
+		result = append(result, arg)
+	}
+
+	return result, nil
+}
+
+// parseVolumes parses the volumes like `docker run -v`.
+// Each volume is `source:target[:options]` or `target` separated by spaces.
+// The source is a host path when it starts with `/`, otherwise a named volume.
+// The relative path is rejected because the path is resolved on the host of the daemon.
+func parseVolumes(volumes string) (map[string]struct{}, []string, error) {
+	anonymous := make(map[string]struct{})
+	var binds []string
+
+	for _, v := range strings.Fields(volumes) {
+		parts := strings.SplitN(v, ":", 3)
+
+		if len(parts) == 1 {
+			if !filepath.IsAbs(v) {
+				return nil, nil, fmt.Errorf("invalid volume: %s", v)
+			}
+
+			anonymous[v] = struct{}{}
+			continue
+		}
+
+		if !filepath.IsAbs(parts[1]) {
+			return nil, nil, fmt.Errorf("invalid volume: %s", v)
+		}
+
+		if strings.HasPrefix(parts[0], ".") || strings.HasPrefix(parts[0], "~") {
+			return nil, nil, fmt.Errorf("host path must be absolute: %s", v)
+		}
+
+		binds = append(binds, strings.Join(parts, ":"))
+	}
+
+	return anonymous, binds, nil
+}
+
+// parseLabels parses `key=value` separated by spaces.
+func parseLabels(labels string) (map[string]string, error) {
+	result := make(map[string]string)
+
+	for _, label := range strings.Fields(labels) {
+		kv := strings.SplitN(label, "=", 2)
+		if kv[0] == "" {
+			return nil, fmt.Errorf("invalid label: %s", label)
+		}
+
+		if len(kv) == 1 {
+			result[kv[0]] = ""
+		} else {
+			result[kv[0]] = kv[1]
+		}
+	}
+
+	return result, nil
+}
+
+// parseIPAM parses the subnet, the gateway and the ip range like `docker network create`.
+// It returns nil if all of them are empty.
+func parseIPAM(subnet, gateway, ipRange string) (*docker.IPAMConfig, error) {
+	if subnet == "" {
+		if gateway != "" || ipRange != "" {
+			return nil, errors.New("gateway and ip range need subnet")
+		}
+
+		return nil, nil
+	}
+
+	_, network, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subnet: %s", subnet)
+	}
+
+	if gateway != "" {
+		ip := net.ParseIP(gateway)
+		if ip == nil || !network.Contains(ip) {
+			return nil, fmt.Errorf("invalid gateway: %s", gateway)
+		}
+	}
+
+	if ipRange != "" {
+		ip, _, err := net.ParseCIDR(ipRange)
+		if err != nil || !network.Contains(ip) {
+			return nil, fmt.Errorf("invalid ip range: %s", ipRange)
+		}
+	}
+
+	return &docker.IPAMConfig{
+		Subnet:  subnet,
+		Gateway: gateway,
+		IPRange: ipRange,
+	}, nil
+}
+
+// parseRestartPolicy parses the policy like `docker run --restart`.
+func parseRestartPolicy(policy string) (docker.RestartPolicy, error) {
+	parts := strings.SplitN(policy, ":", 2)
+
+	// only on-failure has the maximum retry count
+	if len(parts) == 2 && parts[0] != "on-failure" {
+		return docker.RestartPolicy{}, fmt.Errorf("invalid restart policy: %s", policy)
+	}
+
+	switch parts[0] {
+	case "", "no":
+		return docker.NeverRestart(), nil
+	case "always":
+		return docker.AlwaysRestart(), nil
+	case "unless-stopped":
+		return docker.RestartUnlessStopped(), nil
+	case "on-failure":
+		if len(parts) == 1 {
+			return docker.RestartOnFailure(0), nil
+		}
+
+		count, err := strconv.Atoi(parts[1])
+		if err != nil || count < 0 {
+			return docker.RestartPolicy{}, fmt.Errorf("invalid restart policy: %s", policy)
+		}
+
+		return docker.RestartOnFailure(count), nil
+	}
+
+	return docker.RestartPolicy{}, fmt.Errorf("invalid restart policy: %s", policy)
+}
+
+// parseMemory parses the size like `512m` or `1g` to bytes.
+func parseMemory(memory string) (int64, error) {
+	if memory == "" {
+		return 0, nil
+	}
+
+	s := strings.TrimSuffix(strings.ToLower(memory), "b")
+
+	unit := int64(1)
+	switch {
+	case strings.HasSuffix(s, "k"):
+		unit = 1 << 10
+	case strings.HasSuffix(s, "m"):
+		unit = 1 << 20
+	case strings.HasSuffix(s, "g"):
+		unit = 1 << 30
+	case strings.HasSuffix(s, "t"):
+		unit = 1 << 40
+	}
+
+	if unit != 1 {
+		s = s[:len(s)-1]
+	}
+
+	size, err := strconv.ParseFloat(s, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("invalid memory: %s", memory)
+	}
+
+	return int64(size * float64(unit)), nil
+}
+
+// parseCPUs parses the number of CPUs like `1.5` to nano CPUs.
+func parseCPUs(cpus string) (int64, error) {
+	if cpus == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseFloat(cpus, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid cpus: %s", cpus)
+	}
+
+	return int64(n * 1e9), nil
+}
+
+func formatPorts(bindings map[docker.Port][]docker.PortBinding) string {
+	var ports []string
+
+	for port, binds := range bindings {
+		p := port.Port()
+		if proto := port.Proto(); proto != "tcp" {
+			p += "/" + proto
+		}
+
+		for _, bind := range binds {
+			switch {
+			case bind.HostIP != "" && bind.HostIP != "0.0.0.0":
+				ports = append(ports, fmt.Sprintf("%s:%s:%s", bind.HostIP, bind.HostPort, p))
+			case bind.HostPort != "":
+				ports = append(ports, fmt.Sprintf("%s:%s", bind.HostPort, p))
+			default:
+				ports = append(ports, p)
+			}
+		}
+	}
+
+	sort.Strings(ports)
+	return strings.Join(ports, " ")
+}
+
+// formatVolumes returns the binds and the other mounts of the container in the form of parseVolumes.
+// The named and anonymous volumes are given by the name so that the new container uses the same data.
+func formatVolumes(binds []string, mounts []docker.Mount) string {
+	volumes := append([]string{}, binds...)
+
+	bound := make(map[string]bool)
+	for _, bind := range binds {
+		if parts := strings.SplitN(bind, ":", 3); len(parts) > 1 {
+			bound[parts[1]] = true
+		}
+	}
+
+	var others []string
+	for _, m := range mounts {
+		if bound[m.Destination] {
+			continue
+		}
+
+		source := m.Name
+		if source == "" {
+			source = m.Source
+		}
+
+		// tmpfs has no data to keep
+		if source == "" {
+			continue
+		}
+
+		volume := source + ":" + m.Destination
+		if !m.RW {
+			volume += ":ro"
+		}
+
+		others = append(others, volume)
+	}
+
+	sort.Strings(others)
+	return strings.Join(append(volumes, others...), " ")
+}
+
+// missingMounts returns the destinations of the volumes and the host paths of old
+// which are not mounted to the same destinations of new.
+func missingMounts(old, new []docker.Mount) []string {
+	var missing []string
+
+	for _, m := range old {
+		if m.Name == "" && m.Source == "" {
+			continue
+		}
+
+		found := false
+		for _, n := range new {
+			if n.Destination != m.Destination {
+				continue
+			}
+
+			if m.Name != "" && n.Name == m.Name || m.Name == "" && n.Source == m.Source {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, m.Destination)
+		}
+	}
+
+	sort.Strings(missing)
+	return missing
+}
+
+func formatLabels(labels, imageLabels map[string]string) string {
+	var result []string
+
+	for k, v := range labels {
+		if value, ok := imageLabels[k]; ok && value == v {
+			continue
+		}
+		result = append(result, k+"="+v)
+	}
+
+	sort.Strings(result)
+	return strings.Join(result, " ")
+}
+
+func formatRestartPolicy(policy docker.RestartPolicy) string {
+	switch policy.Name {
+	case "":
+		return "no"
+	case "on-failure":
+		if policy.MaximumRetryCount > 0 {
+			return fmt.Sprintf("on-failure:%d", policy.MaximumRetryCount)
+		}
+	}
+
+	return policy.Name
+}
+
+func formatMemory(memory int64) string {
+	switch {
+	case memory == 0:
+		return ""
+	case memory%(1<<30) == 0:
+		return fmt.Sprintf("%dg", memory>>30)
+	case memory%(1<<20) == 0:
+		return fmt.Sprintf("%dm", memory>>20)
+	case memory%(1<<10) == 0:
+		return fmt.Sprintf("%dk", memory>>10)
+	}
+
+	return strconv.FormatInt(memory, 10)
+}
+
+func formatCPUs(nanoCPUs int64) string {
+	if nanoCPUs == 0 {
+		return ""
+	}
+
+	return strconv.FormatFloat(float64(nanoCPUs)/1e9, 'f', -1, 64)
+}
+
+func formatBool(b bool) string {
+	if b {
+		return "y"
+	}
+
+	return "n"
+}
+
+// containerOptions returns the options to create the container with the settings of the form and the image.
+func containerOptions(config map[string]string, image *docker.Image) (docker.CreateContainerOptions, error) {
+	options := docker.CreateContainerOptions{
+		Config:     new(docker.Config),
+		HostConfig: new(docker.HostConfig),
+	}
+
+	if image := config["Image"]; image != "" {
+		options.Config.Image = image
+	} else {
+		return options, fmt.Errorf("no specified image")
+	}
+
+	if name := config["Name"]; name != "" {
+		options.Name = name
+	}
+
+	if image.Config != nil {
+		options.Config.Env = image.Config.Env
+	}
+
+	exposed, bindings, err := parsePorts(config["Ports"])
+	if err != nil {
+		return options, err
+	}
+
+	if len(bindings) > 0 {
+		options.Config.ExposedPorts = exposed
+		options.HostConfig.PortBindings = bindings
+	}
+
+	if cmd := config["Cmd"]; cmd != "" {
+		if options.Config.Cmd, err = common.SplitArgs(cmd); err != nil {
+			return options, err
+		}
+	}
+
+	if entrypoint := config["Entrypoint"]; entrypoint != "" {
+		if options.Config.Entrypoint, err = common.SplitArgs(entrypoint); err != nil {
+			return options, err
+		}
+	}
+
+	env, err := parseEnv(config["Env"])
+	if err != nil {
+		return options, err
+	}
+	options.Config.Env = append(options.Config.Env, env...)
+
+	volumes, binds, err := parseVolumes(config["Volumes"])
+	if err != nil {
+		return options, err
+	}
+
+	if len(volumes) > 0 {
+		options.Config.Volumes = volumes
+	}
+	options.HostConfig.Binds = binds
+
+	labels, err := parseLabels(config["Labels"])
+	if err != nil {
+		return options, err
+	}
+
+	if len(labels) > 0 {
+		options.Config.Labels = labels
+	}
+
+	options.Config.WorkingDir = config["WorkingDir"]
+	options.Config.User = config["User"]
+	options.Config.Hostname = config["Hostname"]
+	options.HostConfig.NetworkMode = config["Network"]
+
+	if options.HostConfig.RestartPolicy, err = parseRestartPolicy(config["Restart"]); err != nil {
+		return options, err
+	}
+
+	if options.HostConfig.Memory, err = parseMemory(config["Memory"]); err != nil {
+		return options, err
+	}
+
+	if options.HostConfig.NanoCPUs, err = parseCPUs(config["CPUs"]); err != nil {
+		return options, err
+	}
+
+	options.HostConfig.CapAdd = strings.Fields(config["CapAdd"])
+	options.HostConfig.Privileged = config["Privileged"] == "y"
+	options.HostConfig.AutoRemove = config["Remove"] == "y"
+
+	if options.HostConfig.AutoRemove && options.HostConfig.RestartPolicy.Name != "no" {
+		return options, fmt.Errorf("Remove and Restart cannot be used together")
+	}
+
+	options.Config.AttachStdout = true
+	options.Config.AttachStderr = true
+
+	if attach := config["Attach"]; attach == "y" {
+		options.Config.Tty = true
+		options.Config.AttachStdin = true
+		options.Config.OpenStdin = true
+	}
+
+	return options, nil
+}
+
+// NewContainerConfig returns the settings of the container in the form of NewContainerOptions
+// so that the container can be cloned or recreated.
+func (d *Docker) NewContainerConfig(id string) (map[string]string, error) {
+	container, err := d.InspectContainer(id)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := d.InspectImage(container.Config.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	return containerConfig(container, image), nil
+}
+
+// containerConfig returns the settings of the container which are not given by the image.
+func containerConfig(container *docker.Container, image *docker.Image) map[string]string {
+	config := container.Config
+	hostConfig := container.HostConfig
+
+	// the settings of the image are given to the new container again
+	imageEnv := make(map[string]bool)
+	var imageLabels map[string]string
+	if image.Config != nil {
+		for _, env := range image.Config.Env {
+			imageEnv[env] = true
+		}
+		imageLabels = image.Config.Labels
+	}
+
+	var env []string
+	for _, e := range config.Env {
+		if !imageEnv[e] {
+			env = append(env, e)
+		}
+	}
+
+	// the host name is the container id by default
+	hostname := config.Hostname
+	if strings.HasPrefix(container.ID, hostname) {
+		hostname = ""
+	}
+
+	network := hostConfig.NetworkMode
+	if network == "default" {
+		network = ""
+	}
+
+	return map[string]string{
+		"Name":       strings.TrimPrefix(container.Name, "/"),
+		"Image":      config.Image,
+		"Cmd":        common.JoinArgs(config.Cmd),
+		"Entrypoint": common.JoinArgs(config.Entrypoint),
+		"Env":        common.JoinArgs(env),
+		"Ports":      formatPorts(hostConfig.PortBindings),
+		"Volumes":    formatVolumes(hostConfig.Binds, container.Mounts),
+		"WorkingDir": config.WorkingDir,
+		"User":       config.User,
+		"Hostname":   hostname,
+		"Labels":     formatLabels(config.Labels, imageLabels),
+		"Network":    network,
+		"Restart":    formatRestartPolicy(hostConfig.RestartPolicy),
+		"Memory":     formatMemory(hostConfig.Memory),
+		"CPUs":       formatCPUs(hostConfig.NanoCPUs),
+		"CapAdd":     strings.Join(hostConfig.CapAdd, " "),
+		"Privileged": formatBool(hostConfig.Privileged),
+		"Remove":     formatBool(hostConfig.AutoRemove),
+		"Attach":     formatBool(config.Tty),
+	}
+}
+
+var signals = map[string]docker.Signal{
+	"ABRT":   docker.SIGABRT,
+	"ALRM":   docker.SIGALRM,
+	"BUS":    docker.SIGBUS,
+	"CHLD":   docker.SIGCHLD,
+	"CONT":   docker.SIGCONT,
+	"FPE":    docker.SIGFPE,
+	"HUP":    docker.SIGHUP,
+	"ILL":    docker.SIGILL,
+	"INT":    docker.SIGINT,
+	"IO":     docker.SIGIO,
+	"KILL":   docker.SIGKILL,
+	"PIPE":   docker.SIGPIPE,
+	"PROF":   docker.SIGPROF,
+	"PWR":    docker.SIGPWR,
+	"QUIT":   docker.SIGQUIT,
+	"SEGV":   docker.SIGSEGV,
+	"STOP":   docker.SIGSTOP,
+	"SYS":    docker.SIGSYS,
+	"TERM":   docker.SIGTERM,
+	"TRAP":   docker.SIGTRAP,
+	"TSTP":   docker.SIGTSTP,
+	"TTIN":   docker.SIGTTIN,
+	"TTOU":   docker.SIGTTOU,
+	"URG":    docker.SIGURG,
+	"USR1":   docker.SIGUSR1,
+	"USR2":   docker.SIGUSR2,
+	"VTALRM": docker.SIGVTALRM,
+	"WINCH":  docker.SIGWINCH,
+	"XCPU":   docker.SIGXCPU,
+	"XFSZ":   docker.SIGXFSZ,
+}
+
+// ParseSignal parses the signal name like `SIGHUP` or `HUP`, or the signal number.
+func ParseSignal(signal string) (docker.Signal, error) {
+	if n, err := strconv.Atoi(signal); err == nil {
+		if n <= 0 || n > 64 {
+			return 0, fmt.Errorf("invalid signal: %s", signal)
+		}
+		return docker.Signal(n), nil
+	}
+
+	sig, ok := signals[strings.TrimPrefix(strings.ToUpper(signal), "SIG")]
+	if !ok {
+		return 0, fmt.Errorf("invalid signal: %s", signal)
+	}
+
+	return sig, nil
+}
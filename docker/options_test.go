@@ -0,0 +1,337 @@
+package docker
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+func TestParseEnv(t *testing.T) {
+	os.Setenv("DOCUI_TEST_ENV", "from env")
+	defer os.Unsetenv("DOCUI_TEST_ENV")
+	os.Unsetenv("DOCUI_TEST_UNSET")
+
+	tests := []struct {
+		env  string
+		want []string
+	}{
+		{"", nil},
+		{"A=1 B=2", []string{"A=1", "B=2"}},
+		{"A=1,2 B=", []string{"A=1,2", "B="}},
+		{"'GREETING=hello, world'", []string{"GREETING=hello, world"}},
+		{"A=$HOME", []string{"A=$HOME"}},
+		{"DOCUI_TEST_ENV", []string{"DOCUI_TEST_ENV=from env"}},
+		{"DOCUI_TEST_UNSET A=1", []string{"A=1"}},
+	}
+
+	for _, tt := range tests {
+		got, err := parseEnv(tt.env)
+		if err != nil {
+			t.Errorf("parseEnv(%q) returned error: %s", tt.env, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseEnv(%q) = %q, want %q", tt.env, got, tt.want)
+		}
+	}
+
+	for _, env := range []string{"=1", "'A=1"} {
+		if _, err := parseEnv(env); err == nil {
+			t.Errorf("parseEnv(%q) returned no error", env)
+		}
+	}
+}
+
+func TestParseVolumes(t *testing.T) {
+	tests := []struct {
+		volumes   string
+		anonymous map[string]struct{}
+		binds     []string
+	}{
+		{"", map[string]struct{}{}, nil},
+		{"/data", map[string]struct{}{"/data": {}}, nil},
+		{
+			"/src:/src:ro data:/var/lib/data /tmp",
+			map[string]struct{}{"/tmp": {}},
+			[]string{"/src:/src:ro", "data:/var/lib/data"},
+		},
+	}
+
+	for _, tt := range tests {
+		anonymous, binds, err := parseVolumes(tt.volumes)
+		if err != nil {
+			t.Errorf("parseVolumes(%q) returned error: %s", tt.volumes, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(anonymous, tt.anonymous) {
+			t.Errorf("parseVolumes(%q) anonymous = %v, want %v", tt.volumes, anonymous, tt.anonymous)
+		}
+
+		if !reflect.DeepEqual(binds, tt.binds) {
+			t.Errorf("parseVolumes(%q) binds = %q, want %q", tt.volumes, binds, tt.binds)
+		}
+	}
+
+	for _, volumes := range []string{"data", "/src:data", "./conf:/etc/app", "../conf:/etc/app", "~/conf:/etc/app"} {
+		if _, _, err := parseVolumes(volumes); err == nil {
+			t.Errorf("parseVolumes(%q) returned no error", volumes)
+		}
+	}
+}
+
+func TestParseLabels(t *testing.T) {
+	tests := []struct {
+		labels string
+		want   map[string]string
+	}{
+		{"", map[string]string{}},
+		{"a=1 b=x=y", map[string]string{"a": "1", "b": "x=y"}},
+		{"a a= b=", map[string]string{"a": "", "b": ""}},
+	}
+
+	for _, tt := range tests {
+		got, err := parseLabels(tt.labels)
+		if err != nil {
+			t.Errorf("parseLabels(%q) returned error: %s", tt.labels, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseLabels(%q) = %v, want %v", tt.labels, got, tt.want)
+		}
+	}
+
+	if _, err := parseLabels("=1"); err == nil {
+		t.Error(`parseLabels("=1") returned no error`)
+	}
+}
+
+func TestParseRestartPolicy(t *testing.T) {
+	tests := []struct {
+		policy string
+		want   docker.RestartPolicy
+	}{
+		{"", docker.NeverRestart()},
+		{"no", docker.NeverRestart()},
+		{"always", docker.AlwaysRestart()},
+		{"unless-stopped", docker.RestartUnlessStopped()},
+		{"on-failure", docker.RestartOnFailure(0)},
+		{"on-failure:3", docker.RestartOnFailure(3)},
+	}
+
+	for _, tt := range tests {
+		got, err := parseRestartPolicy(tt.policy)
+		if err != nil {
+			t.Errorf("parseRestartPolicy(%q) returned error: %s", tt.policy, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("parseRestartPolicy(%q) = %v, want %v", tt.policy, got, tt.want)
+		}
+
+		if policy := formatRestartPolicy(got); policy != tt.policy && tt.policy != "" {
+			t.Errorf("formatRestartPolicy(%v) = %q, want %q", got, policy, tt.policy)
+		}
+	}
+
+	for _, policy := range []string{"sometimes", "on-failure:x", "on-failure:-1", "always:1"} {
+		if _, err := parseRestartPolicy(policy); err == nil {
+			t.Errorf("parseRestartPolicy(%q) returned no error", policy)
+		}
+	}
+}
+
+func TestContainerConfigRoundTrip(t *testing.T) {
+	image := &docker.Image{
+		Config: &docker.Config{
+			Env:    []string{"PATH=/usr/local/bin:/usr/bin", "LANG=C"},
+			Labels: map[string]string{"maintainer": "docui"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		container *docker.Container
+		binds     []string
+	}{
+		{
+			name: "minimal",
+			container: &docker.Container{
+				ID:   "0123456789abcdef",
+				Name: "/minimal",
+				Config: &docker.Config{
+					Image:    "alpine",
+					Hostname: "0123456789ab",
+					Env:      []string{"PATH=/usr/local/bin:/usr/bin", "LANG=C"},
+					Labels:   map[string]string{"maintainer": "docui"},
+				},
+				HostConfig: &docker.HostConfig{
+					NetworkMode:   "default",
+					RestartPolicy: docker.NeverRestart(),
+				},
+			},
+		},
+		{
+			name: "full",
+			container: &docker.Container{
+				ID:   "fedcba9876543210",
+				Name: "/full",
+				Config: &docker.Config{
+					Image:      "app:1.0",
+					Hostname:   "app",
+					Cmd:        []string{"sh", "-c", "echo 'hello, world'"},
+					Entrypoint: []string{"/entrypoint.sh"},
+					Env: []string{
+						"PATH=/usr/local/bin:/usr/bin",
+						"LANG=C",
+						"LIST=a,b,c",
+						"GREETING=hello world",
+						"QUOTE=it's",
+						"EMPTY=",
+						"DOLLAR=$HOME",
+					},
+					Labels:     map[string]string{"maintainer": "docui", "app": "web", "tier": ""},
+					WorkingDir: "/app",
+					User:       "1000:1000",
+					Tty:        true,
+				},
+				HostConfig: &docker.HostConfig{
+					Binds: []string{"/srv/conf:/etc/app:ro", "data:/var/lib/data"},
+					PortBindings: map[docker.Port][]docker.PortBinding{
+						"80/tcp":  {{HostIP: "127.0.0.1", HostPort: "8080"}},
+						"53/udp":  {{HostPort: "53"}},
+						"443/tcp": {{}},
+					},
+					NetworkMode:   "app-net",
+					RestartPolicy: docker.RestartOnFailure(3),
+					Memory:        512 << 20,
+					NanoCPUs:      1500000000,
+					CapAdd:        []string{"NET_ADMIN", "SYS_TIME"},
+					Privileged:    true,
+				},
+				Mounts: []docker.Mount{
+					{Source: "/srv/conf", Destination: "/etc/app"},
+					{Name: "data", Source: "/var/lib/docker/volumes/data/_data", Destination: "/var/lib/data", RW: true},
+					{Name: "3f1c", Source: "/var/lib/docker/volumes/3f1c/_data", Destination: "/cache", RW: true},
+					{Source: "/srv/logs", Destination: "/logs", RW: true},
+					{Destination: "/run"},
+				},
+			},
+			binds: []string{"/srv/conf:/etc/app:ro", "data:/var/lib/data", "/srv/logs:/logs", "3f1c:/cache"},
+		},
+	}
+
+	for _, tt := range tests {
+		config := containerConfig(tt.container, image)
+
+		options, err := containerOptions(config, image)
+		if err != nil {
+			t.Errorf("%s: containerOptions returned error: %s", tt.name, err)
+			continue
+		}
+
+		want, got := tt.container, options
+		if got.Name != want.Name[1:] {
+			t.Errorf("%s: Name = %q, want %q", tt.name, got.Name, want.Name[1:])
+		}
+
+		if !reflect.DeepEqual(got.Config.Env, want.Config.Env) {
+			t.Errorf("%s: Env = %q, want %q", tt.name, got.Config.Env, want.Config.Env)
+		}
+
+		if !reflect.DeepEqual(got.Config.Cmd, want.Config.Cmd) {
+			t.Errorf("%s: Cmd = %q, want %q", tt.name, got.Config.Cmd, want.Config.Cmd)
+		}
+
+		if !reflect.DeepEqual(got.Config.Entrypoint, want.Config.Entrypoint) {
+			t.Errorf("%s: Entrypoint = %q, want %q", tt.name, got.Config.Entrypoint, want.Config.Entrypoint)
+		}
+
+		if !reflect.DeepEqual(got.HostConfig.Binds, tt.binds) {
+			t.Errorf("%s: Binds = %q, want %q", tt.name, got.HostConfig.Binds, tt.binds)
+		}
+
+		if len(want.HostConfig.PortBindings) > 0 && !reflect.DeepEqual(got.HostConfig.PortBindings, want.HostConfig.PortBindings) {
+			t.Errorf("%s: PortBindings = %v, want %v", tt.name, got.HostConfig.PortBindings, want.HostConfig.PortBindings)
+		}
+
+		// the labels of the image are given by the image again
+		labels := make(map[string]string)
+		for k, v := range want.Config.Labels {
+			if value, ok := image.Config.Labels[k]; !ok || value != v {
+				labels[k] = v
+			}
+		}
+		if len(labels) > 0 && !reflect.DeepEqual(got.Config.Labels, labels) {
+			t.Errorf("%s: Labels = %v, want %v", tt.name, got.Config.Labels, labels)
+		}
+
+		if got.HostConfig.RestartPolicy != want.HostConfig.RestartPolicy {
+			t.Errorf("%s: RestartPolicy = %v, want %v", tt.name, got.HostConfig.RestartPolicy, want.HostConfig.RestartPolicy)
+		}
+
+		if got.HostConfig.Memory != want.HostConfig.Memory || got.HostConfig.NanoCPUs != want.HostConfig.NanoCPUs {
+			t.Errorf("%s: Memory, NanoCPUs = %d, %d, want %d, %d", tt.name,
+				got.HostConfig.Memory, got.HostConfig.NanoCPUs, want.HostConfig.Memory, want.HostConfig.NanoCPUs)
+		}
+
+		if len(want.HostConfig.CapAdd) > 0 && !reflect.DeepEqual(got.HostConfig.CapAdd, want.HostConfig.CapAdd) {
+			t.Errorf("%s: CapAdd = %q, want %q", tt.name, got.HostConfig.CapAdd, want.HostConfig.CapAdd)
+		}
+
+		if got.HostConfig.Privileged != want.HostConfig.Privileged || got.Config.Tty != want.Config.Tty {
+			t.Errorf("%s: Privileged, Tty = %t, %t, want %t, %t", tt.name,
+				got.HostConfig.Privileged, got.Config.Tty, want.HostConfig.Privileged, want.Config.Tty)
+		}
+
+		if got.Config.WorkingDir != want.Config.WorkingDir || got.Config.User != want.Config.User {
+			t.Errorf("%s: WorkingDir, User = %q, %q, want %q, %q", tt.name,
+				got.Config.WorkingDir, got.Config.User, want.Config.WorkingDir, want.Config.User)
+		}
+
+		// the default host name and network are not given explicitly
+		if tt.name == "full" && (got.Config.Hostname != want.Config.Hostname || got.HostConfig.NetworkMode != want.HostConfig.NetworkMode) {
+			t.Errorf("%s: Hostname, NetworkMode = %q, %q, want %q, %q", tt.name,
+				got.Config.Hostname, got.HostConfig.NetworkMode, want.Config.Hostname, want.HostConfig.NetworkMode)
+		}
+		if tt.name == "minimal" && (got.Config.Hostname != "" || got.HostConfig.NetworkMode != "") {
+			t.Errorf("%s: Hostname, NetworkMode = %q, %q, want empty", tt.name, got.Config.Hostname, got.HostConfig.NetworkMode)
+		}
+	}
+}
+
+func TestMissingMounts(t *testing.T) {
+	old := []docker.Mount{
+		{Source: "/srv/conf", Destination: "/etc/app"},
+		{Name: "data", Destination: "/var/lib/data"},
+		{Name: "3f1c", Destination: "/cache"},
+		{Destination: "/run"},
+	}
+
+	tests := []struct {
+		new  []docker.Mount
+		want []string
+	}{
+		{old, nil},
+		{nil, []string{"/cache", "/etc/app", "/var/lib/data"}},
+		{
+			[]docker.Mount{
+				{Source: "/srv/other", Destination: "/etc/app"},
+				{Name: "data", Destination: "/data"},
+				{Name: "3f1c", Destination: "/cache"},
+			},
+			[]string{"/etc/app", "/var/lib/data"},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := missingMounts(old, tt.new); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("missingMounts(%v) = %q, want %q", tt.new, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package docker
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// PruneReport is the result of the prune.
+type PruneReport struct {
+	Deleted        []string
+	SpaceReclaimed int64
+}
+
+// NewPruneFilters returns the filters of the prune.
+// until is a duration like `24h` or a timestamp, and labels are separated by space.
+// The label starting with `!` removes the objects which do not have the label.
+func NewPruneFilters(until, labels string) map[string][]string {
+	filters := make(map[string][]string)
+
+	if until != "" {
+		filters["until"] = []string{until}
+	}
+
+	for _, label := range strings.Fields(labels) {
+		if strings.HasPrefix(label, "!") {
+			filters["label!"] = append(filters["label!"], label[1:])
+		} else {
+			filters["label"] = append(filters["label"], label)
+		}
+	}
+
+	return filters
+}
+
+// PruneStoppedContainers removes all stopped containers.
+func (d *Docker) PruneStoppedContainers() (*PruneReport, error) {
+	result, err := d.Client.PruneContainers(docker.PruneContainersOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PruneReport{
+		Deleted:        result.ContainersDeleted,
+		SpaceReclaimed: result.SpaceReclaimed,
+	}, nil
+}
+
+// PruneUnusedNetworks removes all networks not used by any containers.
+func (d *Docker) PruneUnusedNetworks() (*PruneReport, error) {
+	result, err := d.Client.PruneNetworks(docker.PruneNetworksOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PruneReport{
+		Deleted: result.NetworksDeleted,
+	}, nil
+}
+
+// PruneImagesWithFilters removes the dangling images, or all unused images if all is true.
+func (d *Docker) PruneImagesWithFilters(all bool, filters map[string][]string) (*PruneReport, error) {
+	if filters == nil {
+		filters = make(map[string][]string)
+	}
+
+	filters["dangling"] = []string{fmt.Sprint(!all)}
+
+	result, err := d.Client.PruneImages(docker.PruneImagesOptions{Filters: filters})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PruneReport{
+		SpaceReclaimed: result.SpaceReclaimed,
+	}
+
+	for _, image := range result.ImagesDeleted {
+		if image.Deleted != "" {
+			report.Deleted = append(report.Deleted, image.Deleted)
+		}
+	}
+
+	return report, nil
+}
+
+// PruneUnusedVolumes removes all volumes not used by any containers.
+func (d *Docker) PruneUnusedVolumes() (*PruneReport, error) {
+	result, err := d.Client.PruneVolumes(docker.PruneVolumesOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PruneReport{
+		Deleted:        result.VolumesDeleted,
+		SpaceReclaimed: result.SpaceReclaimed,
+	}, nil
+}
+
+// PruneBuildCache removes the dangling build cache, or all build cache not in use if all is true.
+func (d *Docker) PruneBuildCache(all bool) (*PruneReport, error) {
+	var result struct {
+		CachesDeleted  []string
+		SpaceReclaimed int64
+	}
+
+	path := "/build/prune"
+	if all {
+		path += "?all=true"
+	}
+
+	if err := d.request(http.MethodPost, path, &result); err != nil {
+		return nil, err
+	}
+
+	return &PruneReport{
+		Deleted:        result.CachesDeleted,
+		SpaceReclaimed: result.SpaceReclaimed,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// request requests to the docker daemon for the APIs go-dockerclient does not support,
+// and decodes the response to v.
+func (d *Docker) request(method, path string, v interface{}) error {
+	return d.send(method, path, nil, nil, v)
+}
+
+// send sends body encoded as json with header, and decodes the response to v if v is not nil.
+// The http client of go-dockerclient is used as it is, so the transport and the timeout are shared.
+func (d *Docker) send(method, path string, header http.Header, body, v interface{}) error {
+	_, data, err := d.do(method, path, header, body)
+	if err != nil {
+		return err
+	}
+
+	if v == nil || len(data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// do sends body encoded as json with header, and returns the header and the body of the response.
+// The response which is not 2xx is returned as an error.
+func (d *Docker) do(method, path string, header http.Header, body interface{}) (http.Header, []byte, error) {
+	base, err := d.baseURL()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, base+path, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for k, values := range header {
+		req.Header[k] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := d.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var message struct {
+			Message string `json:"message"`
+		}
+
+		if err := json.Unmarshal(data, &message); err != nil || message.Message == "" {
+			return nil, nil, fmt.Errorf("%s %s: %s", method, path, resp.Status)
+		}
+
+		return nil, nil, errors.New(message.Message)
+	}
+
+	return resp.Header, data, nil
+}
+
+// baseURL returns the url of the daemon for the http client.
+func (d *Docker) baseURL() (string, error) {
+	endpoint, err := url.Parse(d.Endpoint())
+	if err != nil {
+		return "", err
+	}
+
+	switch endpoint.Scheme {
+	case "unix":
+		// the transport of go-dockerclient dials the socket whatever the host is
+		return "http://unix.sock", nil
+	case "https":
+		return "https://" + endpoint.Host, nil
+	case "http", "tcp":
+		if d.TLSConfig != nil {
+			return "https://" + endpoint.Host, nil
+		}
+		return "http://" + endpoint.Host, nil
+	}
+
+	return "", fmt.Errorf("%s is not supported by this operation", endpoint.Scheme)
+}
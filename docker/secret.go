@@ -0,0 +1,181 @@
+package docker
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrSecretData is returned when neither or both of the file and the value are given.
+var ErrSecretData = errors.New("please input either File or Value")
+
+// SwarmObject is the metadata of the secret or the config of the swarm.
+// The data of the secret is never returned from the daemon.
+type SwarmObject struct {
+	ID        string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Spec      struct {
+		Name   string
+		Labels map[string]string
+	}
+}
+
+// Labels returns the labels like `key=value` sorted by the key.
+func (o *SwarmObject) Labels() string {
+	var labels []string
+	for k, v := range o.Spec.Labels {
+		labels = append(labels, k+"="+v)
+	}
+
+	sort.Strings(labels)
+	return strings.Join(labels, " ")
+}
+
+// Secrets returns the secrets of the swarm.
+func (d *Docker) Secrets() ([]*SwarmObject, error) {
+	return d.swarmObjects("secret")
+}
+
+// Configs returns the configs of the swarm.
+func (d *Docker) Configs() ([]*SwarmObject, error) {
+	return d.swarmObjects("config")
+}
+
+// SecretInfo returns the metadata of the secret.
+func (d *Docker) SecretInfo(id string) (map[string]interface{}, error) {
+	secret, err := d.swarmObjectInfo("secret", id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the daemon does not return the data, but never show it
+	if spec, ok := secret["Spec"].(map[string]interface{}); ok {
+		delete(spec, "Data")
+	}
+
+	return secret, nil
+}
+
+// ConfigInfo returns the config.
+func (d *Docker) ConfigInfo(id string) (map[string]interface{}, error) {
+	return d.swarmObjectInfo("config", id)
+}
+
+// CreateSecret creates the secret with the values of the create secret panel.
+func (d *Docker) CreateSecret(data map[string]string) error {
+	return d.createSwarmObject("secret", data)
+}
+
+// CreateConfig creates the config with the values of the create config panel.
+func (d *Docker) CreateConfig(data map[string]string) error {
+	return d.createSwarmObject("config", data)
+}
+
+// RemoveSecret removes the secret.
+func (d *Docker) RemoveSecret(id string) error {
+	return d.request(http.MethodDelete, "/secrets/"+id, nil)
+}
+
+// RemoveConfig removes the config.
+func (d *Docker) RemoveConfig(id string) error {
+	return d.request(http.MethodDelete, "/configs/"+id, nil)
+}
+
+// SecretReferences returns the names of the services using the secret.
+func (d *Docker) SecretReferences(id string) ([]string, error) {
+	return d.references(func(service *Service) bool {
+		for _, secret := range service.Spec.TaskTemplate.ContainerSpec.Secrets {
+			if secret.SecretID == id {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// ConfigReferences returns the names of the services using the config.
+func (d *Docker) ConfigReferences(id string) ([]string, error) {
+	return d.references(func(service *Service) bool {
+		for _, config := range service.Spec.TaskTemplate.ContainerSpec.Configs {
+			if config.ConfigID == id {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (d *Docker) swarmObjects(kind string) ([]*SwarmObject, error) {
+	var objects []*SwarmObject
+	if err := d.request(http.MethodGet, "/"+kind+"s", &objects); err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+func (d *Docker) swarmObjectInfo(kind, id string) (map[string]interface{}, error) {
+	var object map[string]interface{}
+	if err := d.request(http.MethodGet, "/"+kind+"s/"+id, &object); err != nil {
+		return nil, err
+	}
+
+	return object, nil
+}
+
+// createSwarmObject creates the secret or the config.
+// The data is read from the file on the host, or the value is used as it is.
+func (d *Docker) createSwarmObject(kind string, data map[string]string) error {
+	if (data["File"] == "") == (data["Value"] == "") {
+		return ErrSecretData
+	}
+
+	value := []byte(data["Value"])
+	if data["File"] != "" {
+		b, err := ioutil.ReadFile(data["File"])
+		if err != nil {
+			return err
+		}
+		value = b
+	}
+
+	labels, err := parseLabels(data["Labels"])
+	if err != nil {
+		return err
+	}
+
+	// []byte is encoded to base64
+	spec := struct {
+		Name   string
+		Labels map[string]string
+		Data   []byte
+	}{
+		Name:   data["Name"],
+		Labels: labels,
+		Data:   value,
+	}
+
+	return d.send(http.MethodPost, "/"+kind+"s/create", nil, spec, nil)
+}
+
+// references returns the names of the services matched with f.
+func (d *Docker) references(f func(service *Service) bool) ([]string, error) {
+	var services []*Service
+	if err := d.request(http.MethodGet, "/services", &services); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, service := range services {
+		if f(service) {
+			names = append(names, service.Spec.Name)
+		}
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
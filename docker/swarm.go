@@ -0,0 +1,376 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrNotReplicated is returned when scaling the global service.
+var ErrNotReplicated = errors.New("scale can only be used with replicated mode")
+
+// Service is the swarm service.
+// go-dockerclient does not support swarm, so the fields docui uses are decoded from the API.
+type Service struct {
+	ID        string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Version   struct {
+		Index uint64
+	}
+	Spec struct {
+		Name         string
+		TaskTemplate struct {
+			ContainerSpec struct {
+				Image   string
+				Secrets []struct {
+					SecretID   string
+					SecretName string
+				}
+				Configs []struct {
+					ConfigID   string
+					ConfigName string
+				}
+			}
+		}
+		Mode struct {
+			Replicated *struct {
+				Replicas *uint64
+			}
+			Global *struct{}
+		}
+	}
+	Endpoint struct {
+		Ports []PortConfig
+	}
+	UpdateStatus *struct {
+		State   string
+		Message string
+	}
+	ServiceStatus *ServiceStatus
+}
+
+// ServiceStatus is the number of the running and desired tasks of the service.
+type ServiceStatus struct {
+	RunningTasks uint64
+	DesiredTasks uint64
+}
+
+// PortConfig is the published port of the service.
+type PortConfig struct {
+	Protocol      string
+	TargetPort    uint32
+	PublishedPort uint32
+	PublishMode   string
+}
+
+// Task is the task of the swarm service.
+type Task struct {
+	ID           string
+	ServiceID    string
+	NodeID       string
+	Slot         int
+	DesiredState string
+	CreatedAt    time.Time
+	Spec         struct {
+		ContainerSpec struct {
+			Image string
+		}
+	}
+	Status struct {
+		Timestamp time.Time
+		State     string
+		Message   string
+		Err       string
+	}
+}
+
+// Node is the node of the swarm.
+type Node struct {
+	ID      string
+	Version struct {
+		Index uint64
+	}
+	Spec struct {
+		Role         string
+		Availability string
+	}
+	Description struct {
+		Hostname string
+		Engine   struct {
+			EngineVersion string
+		}
+	}
+	Status struct {
+		State string
+		Addr  string
+	}
+	ManagerStatus *struct {
+		Leader       bool
+		Reachability string
+	}
+}
+
+// Manager returns `Leader` or the reachability of the manager, or empty for the worker.
+func (n *Node) Manager() string {
+	switch {
+	case n.ManagerStatus == nil:
+		return ""
+	case n.ManagerStatus.Leader:
+		return "Leader"
+	}
+
+	return strings.Title(n.ManagerStatus.Reachability)
+}
+
+// Mode returns the mode of the service.
+func (s *Service) Mode() string {
+	if s.Spec.Mode.Global != nil {
+		return "global"
+	}
+
+	return "replicated"
+}
+
+// Ports returns the published ports like `*:8080->80/tcp`.
+func (s *Service) Ports() string {
+	var ports []string
+	for _, port := range s.Endpoint.Ports {
+		if port.PublishedPort == 0 {
+			continue
+		}
+
+		ports = append(ports, fmt.Sprintf("*:%d->%d/%s", port.PublishedPort, port.TargetPort, port.Protocol))
+	}
+
+	return strings.Join(ports, ", ")
+}
+
+// IsSwarmManager returns true if the daemon is a manager of the swarm.
+func (d *Docker) IsSwarmManager() bool {
+	var info struct {
+		Swarm struct {
+			LocalNodeState   string
+			ControlAvailable bool
+		}
+	}
+
+	if err := d.request(http.MethodGet, "/info", &info); err != nil {
+		return false
+	}
+
+	return info.Swarm.LocalNodeState == "active" && info.Swarm.ControlAvailable
+}
+
+// Services returns the services with the number of the running and desired tasks.
+// The daemon older than API 1.41 does not return them, so they are counted from the tasks.
+func (d *Docker) Services() ([]*Service, error) {
+	var services []*Service
+	if err := d.request(http.MethodGet, "/services?status=true", &services); err != nil {
+		return nil, err
+	}
+
+	counted := true
+	for _, service := range services {
+		if service.ServiceStatus == nil {
+			counted = false
+			break
+		}
+	}
+
+	if counted {
+		return services, nil
+	}
+
+	tasks, err := d.Tasks(map[string][]string{"desired-state": {"running"}})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, service := range services {
+		service.ServiceStatus = &ServiceStatus{}
+
+		for _, task := range tasks {
+			if task.ServiceID != service.ID {
+				continue
+			}
+
+			service.ServiceStatus.DesiredTasks++
+			if task.Status.State == "running" {
+				service.ServiceStatus.RunningTasks++
+			}
+		}
+
+		if replicated := service.Spec.Mode.Replicated; replicated != nil && replicated.Replicas != nil {
+			service.ServiceStatus.DesiredTasks = *replicated.Replicas
+		}
+	}
+
+	return services, nil
+}
+
+// ServiceInfo returns the service as it is returned from the daemon.
+func (d *Docker) ServiceInfo(id string) (map[string]interface{}, error) {
+	var service map[string]interface{}
+	if err := d.request(http.MethodGet, "/services/"+id, &service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+// Tasks returns the tasks filtered by the service or the node.
+func (d *Docker) Tasks(filters map[string][]string) ([]*Task, error) {
+	f, err := json.Marshal(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []*Task
+	if err := d.request(http.MethodGet, "/tasks?filters="+url.QueryEscape(string(f)), &tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// Nodes returns the nodes of the swarm.
+func (d *Docker) Nodes() ([]*Node, error) {
+	var nodes []*Node
+	if err := d.request(http.MethodGet, "/nodes", &nodes); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// NodeInfo returns the node as it is returned from the daemon.
+func (d *Docker) NodeInfo(id string) (map[string]interface{}, error) {
+	var node map[string]interface{}
+	if err := d.request(http.MethodGet, "/nodes/"+id, &node); err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// UpdateNodeRole promotes the node to the manager or demotes it to the worker.
+func (d *Docker) UpdateNodeRole(id, role string) error {
+	return d.updateNode(id, "Role", role)
+}
+
+// UpdateNodeAvailability changes the availability of the node to active, pause or drain.
+func (d *Docker) UpdateNodeAvailability(id, availability string) error {
+	return d.updateNode(id, "Availability", availability)
+}
+
+func (d *Docker) updateNode(id, key, value string) error {
+	return d.update("/nodes/", id, url.Values{}, nil, func(spec map[string]interface{}) error {
+		spec[key] = value
+		return nil
+	})
+}
+
+// ScaleService changes the number of the replicas of the service.
+func (d *Docker) ScaleService(id string, replicas uint64) error {
+	return d.update("/services/", id, url.Values{}, nil, func(spec map[string]interface{}) error {
+		replicated, ok := specMap(spec, "Mode", "Replicated")
+		if !ok {
+			return ErrNotReplicated
+		}
+
+		replicated["Replicas"] = replicas
+		return nil
+	})
+}
+
+// UpdateServiceImage updates the image of the service with the credentials of the registry.
+func (d *Docker) UpdateServiceImage(id, image string) error {
+	header := http.Header{}
+	if auth, err := AuthConfigForImage(image); err == nil {
+		b, err := json.Marshal(auth)
+		if err != nil {
+			return err
+		}
+		header.Set("X-Registry-Auth", base64.URLEncoding.EncodeToString(b))
+	}
+
+	return d.update("/services/", id, url.Values{}, header, func(spec map[string]interface{}) error {
+		container, ok := specMap(spec, "TaskTemplate", "ContainerSpec")
+		if !ok {
+			return fmt.Errorf("service %s has no container spec", id)
+		}
+
+		container["Image"] = image
+		return nil
+	})
+}
+
+// RollbackService reverts the service to the previous spec.
+func (d *Docker) RollbackService(id string) error {
+	query := url.Values{
+		"rollback":         {"previous"},
+		"registryAuthFrom": {"previous-spec"},
+	}
+
+	return d.update("/services/", id, query, nil, func(spec map[string]interface{}) error {
+		return nil
+	})
+}
+
+// RemoveService removes the service.
+func (d *Docker) RemoveService(id string) error {
+	return d.request(http.MethodDelete, "/services/"+id, nil)
+}
+
+// update updates the swarm object such as the service or the node with the current spec changed by f.
+// The spec is kept as it is to not drop the fields docui does not know.
+func (d *Docker) update(path, id string, query url.Values, header http.Header, f func(spec map[string]interface{}) error) error {
+	var object struct {
+		ID      string
+		Version struct {
+			Index uint64
+		}
+		Spec json.RawMessage
+	}
+
+	if err := d.request(http.MethodGet, path+id, &object); err != nil {
+		return err
+	}
+
+	// keep the numbers such as nanoseconds as they are
+	var spec map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(object.Spec))
+	decoder.UseNumber()
+	if err := decoder.Decode(&spec); err != nil {
+		return err
+	}
+
+	if err := f(spec); err != nil {
+		return err
+	}
+
+	query.Set("version", fmt.Sprint(object.Version.Index))
+
+	return d.send(http.MethodPost, path+object.ID+"/update?"+query.Encode(), header, spec, nil)
+}
+
+// specMap returns the nested object of the spec.
+func specMap(spec map[string]interface{}, keys ...string) (map[string]interface{}, bool) {
+	m := spec
+	for _, key := range keys {
+		next, ok := m[key].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		m = next
+	}
+
+	return m, true
+}
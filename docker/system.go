@@ -0,0 +1,54 @@
+package docker
+
+import (
+	"net/http"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// DiskUsage is the disk usage of the docker daemon like `docker system df`.
+type DiskUsage struct {
+	LayersSize int64
+	Images     []*docker.ImageSummary
+	Containers []*docker.APIContainers
+	Volumes    []*VolumeUsage
+	BuildCache []*BuildCache
+}
+
+// VolumeUsage is the volume with the usage.
+// Size and RefCount are -1 if the driver does not support them.
+type VolumeUsage struct {
+	Name      string
+	Driver    string
+	CreatedAt time.Time
+	UsageData struct {
+		Size     int64
+		RefCount int64
+	}
+}
+
+// BuildCache is the build cache record.
+type BuildCache struct {
+	ID          string
+	Type        string
+	Description string
+	InUse       bool
+	Shared      bool
+	Size        int64
+	CreatedAt   time.Time
+	LastUsedAt  *time.Time
+	UsageCount  int
+}
+
+// DiskUsage returns the disk usage of images, containers, volumes and build cache.
+// go-dockerclient does not decode the usage of volumes and build cache,
+// so it requests to the daemon directly.
+func (d *Docker) DiskUsage() (*DiskUsage, error) {
+	usage := &DiskUsage{}
+	if err := d.request(http.MethodGet, "/system/df", usage); err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
@@ -1,13 +1,37 @@
 package main
 
 import (
+	"flag"
+	"os"
+
+	"github.com/skanehira/docui/docker"
 	"github.com/skanehira/docui/panel"
 
 	"github.com/jroimartin/gocui"
 )
 
 func main() {
-	gui := panel.New(gocui.Output256)
+	config := docker.NewClientConfigFromEnv()
+
+	flag.StringVar(&config.Endpoint, "host", config.Endpoint, "docker daemon endpoint (unix://, tcp:// or https://)")
+	flag.StringVar(&config.CertPath, "certpath", config.CertPath, "directory of ca.pem, cert.pem and key.pem")
+	flag.BoolVar(&config.TLS, "tls", config.TLS, "use TLS without verifying the daemon certificate")
+	flag.BoolVar(&config.TLSVerify, "tlsverify", config.TLSVerify, "use TLS and verify the daemon certificate")
+	context := flag.String("context", docker.CurrentContext(), "docker cli context name")
+	stopTimeout := flag.Uint("stoptimeout", 30, "seconds to wait for stop before killing the container")
+	flag.Parse()
+
+	// like docker cli, the endpoint specified explicitly wins over the current context
+	specified := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		specified[f.Name] = true
+	})
+
+	if (specified["host"] || os.Getenv("DOCKER_HOST") != "") && !specified["context"] {
+		*context = docker.DefaultContext
+	}
+
+	gui := panel.New(gocui.Output256, config, *context, *stopTimeout)
 	defer gui.Close()
 
 	if err := gui.MainLoop(); err != nil && err != gocui.ErrQuit {
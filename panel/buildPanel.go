@@ -0,0 +1,256 @@
+package panel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/jroimartin/gocui"
+)
+
+type Build struct {
+	*Gui
+	Position
+	name    string
+	options docker.BuildImageOptions
+	tags    []string
+	cancel  context.CancelFunc
+	done    bool
+}
+
+func NewBuild(gui *Gui, name string, x, y, w, h int, options docker.BuildImageOptions, tags []string) *Build {
+	return &Build{
+		Gui:      gui,
+		name:     name,
+		Position: Position{x, y, w, h},
+		options:  options,
+		tags:     tags,
+	}
+}
+
+func (b *Build) Name() string {
+	return b.name
+}
+
+func (b *Build) SetView(g *gocui.Gui) error {
+	v, err := g.SetView(b.name, b.x, b.y, b.w, b.h)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Wrap = true
+		v.Autoscroll = true
+		v.Title = b.title("building")
+	}
+
+	b.SetKeyBinding()
+	b.SwitchPanel(b.name)
+	b.Stream()
+
+	return nil
+}
+
+func (b *Build) SetKeyBinding() {
+	if err := b.SetKeybinding(b.name, 'j', gocui.ModNone, CursorDown); err != nil {
+		panic(err)
+	}
+	if err := b.SetKeybinding(b.name, 'k', gocui.ModNone, b.scroll(CursorUp)); err != nil {
+		panic(err)
+	}
+	if err := b.SetKeybinding(b.name, 'd', gocui.ModNone, PageDown); err != nil {
+		panic(err)
+	}
+	if err := b.SetKeybinding(b.name, 'u', gocui.ModNone, b.scroll(PageUp)); err != nil {
+		panic(err)
+	}
+	if err := b.SetKeybinding(b.name, gocui.KeyCtrlC, gocui.ModNone, b.Cancel); err != nil {
+		panic(err)
+	}
+	if err := b.SetKeybinding(b.name, gocui.KeyEsc, gocui.ModNone, b.ClosePanel); err != nil {
+		panic(err)
+	}
+	if err := b.SetKeybinding(b.name, 'q', gocui.ModNone, b.ClosePanel); err != nil {
+		panic(err)
+	}
+	if err := b.SetKeybinding(b.name, gocui.KeyCtrlQ, gocui.ModNone, b.quit); err != nil {
+		panic(err)
+	}
+}
+
+// scroll stops the autoscroll so that the user can read back the output while building.
+func (b *Build) scroll(f func(g *gocui.Gui, v *gocui.View) error) func(g *gocui.Gui, v *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		v.Autoscroll = false
+		return f(g, v)
+	}
+}
+
+func (b *Build) Refresh(g *gocui.Gui, v *gocui.View) error {
+	return nil
+}
+
+// Stream starts the build and writes the output to the view.
+func (b *Build) Stream() {
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+
+	stdout := &viewWriter{Gui: b.Gui, ctx: ctx, name: b.name}
+	stderr := &viewWriter{Gui: b.Gui, ctx: ctx, name: b.name, color: "\x1b[31m"}
+
+	r, w := io.Pipe()
+
+	options := b.options
+	options.Context = ctx
+	options.OutputStream = w
+	options.RawJSONStream = true
+
+	go func() {
+		w.CloseWithError(b.Docker.BuildImageWithOptions(options))
+	}()
+
+	go func() {
+		decoder := json.NewDecoder(r)
+
+		var (
+			step   string
+			errMsg string
+			err    error
+		)
+
+		for {
+			var msg jsonMessage
+			if err = decoder.Decode(&msg); err != nil {
+				break
+			}
+
+			switch {
+			case msg.Error != "":
+				errMsg = msg.Error
+			case msg.Stream != "":
+				if strings.HasPrefix(msg.Stream, "Step ") {
+					step = strings.TrimSpace(msg.Stream)
+				}
+				fmt.Fprint(stdout, msg.Stream)
+			case msg.Status != "" && msg.Progress == "":
+				// the status of pulling the base image
+				if msg.ID != "" {
+					fmt.Fprintf(stdout, "%s: %s\n", msg.ID, msg.Status)
+				} else {
+					fmt.Fprintln(stdout, msg.Status)
+				}
+			}
+		}
+		r.Close()
+
+		if err == io.EOF {
+			err = nil
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err == nil && errMsg == "" {
+			err = b.tag()
+		}
+
+		state := "done"
+		if err != nil || errMsg != "" {
+			state = "failed"
+
+			if errMsg == "" {
+				errMsg = err.Error()
+			}
+
+			if step != "" {
+				fmt.Fprintf(stderr, "\nfailed at %s\n%s\n", step, errMsg)
+			} else {
+				fmt.Fprintf(stderr, "\n%s\n", errMsg)
+			}
+		}
+
+		b.Update(func(g *gocui.Gui) error {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			b.done = true
+
+			if v, err := g.View(b.name); err == nil {
+				v.Title = b.title(state)
+			}
+
+			if state == "done" {
+				b.Panels[ImageListPanel].Refresh(g, nil)
+			}
+
+			return nil
+		})
+	}()
+}
+
+func (b *Build) title(state string) string {
+	name := b.options.Name
+	if name == "" {
+		name = b.options.ContextDir
+	}
+
+	return fmt.Sprintf("%s %s (%s)", b.name, name, state)
+}
+
+// tag tags the built image with the rest of the tags.
+func (b *Build) tag() error {
+	for _, tag := range b.tags {
+		repo, t := docker.ParseRepositoryTag(tag)
+		if t == "" {
+			t = "latest"
+		}
+
+		options := docker.TagImageOptions{
+			Repo: repo,
+			Tag:  t,
+		}
+
+		if err := b.Docker.TagImageWithOptions(b.options.Name, options); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Cancel stops the build.
+func (b *Build) Cancel(g *gocui.Gui, v *gocui.View) error {
+	if b.done {
+		return nil
+	}
+
+	b.Stop()
+	v.Title = b.title("canceled")
+	return nil
+}
+
+// Stop stops the build.
+func (b *Build) Stop() {
+	if b.cancel != nil {
+		b.cancel()
+		b.cancel = nil
+	}
+}
+
+func (b *Build) ClosePanel(g *gocui.Gui, v *gocui.View) error {
+	b.Stop()
+
+	if err := b.DeleteView(b.name); err != nil {
+		panic(err)
+	}
+	b.DeleteKeybindings(b.name)
+
+	b.SwitchPanel(b.NextPanel)
+
+	return nil
+}
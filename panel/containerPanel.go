@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"time"
 
 	docker "github.com/fsouza/go-dockerclient"
 	"github.com/jroimartin/gocui"
@@ -21,6 +20,7 @@ type ContainerList struct {
 	Items             Items
 	selectedContainer *Container
 	filter            string
+	marked            Marks
 }
 
 type Container struct {
@@ -39,6 +39,7 @@ func NewContainerList(gui *Gui, name string, x, y, w, h int) *ContainerList {
 		Position: Position{x, y, w, h},
 		Data:     make(map[string]interface{}),
 		Items:    Items{},
+		marked:   make(Marks),
 	}
 }
 
@@ -103,17 +104,6 @@ func (c *ContainerList) SetView(g *gocui.Gui) error {
 
 	c.SetKeyBinding()
 
-	//monitoring container status interval 5s
-	go func() {
-		for {
-			c.Update(func(g *gocui.Gui) error {
-				c.Refresh(g, v)
-				return nil
-			})
-			time.Sleep(5 * time.Second)
-		}
-	}()
-
 	return nil
 }
 
@@ -156,6 +146,54 @@ func (c *ContainerList) SetKeyBinding() {
 	if err := c.SetKeybinding(c.name, 'f', gocui.ModNone, c.Filter); err != nil {
 		panic(err)
 	}
+	if err := c.SetKeybinding(c.name, 'L', gocui.ModNone, c.LogsPanel); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, 'x', gocui.ModNone, c.ExecContainerPanel); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, 'S', gocui.ModNone, c.StatsPanel); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, gocui.KeySpace, gocui.ModNone, c.Mark); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, 'a', gocui.ModNone, c.MarkAll); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, 'C', gocui.ModNone, c.CloneContainerPanel); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, 'R', gocui.ModNone, c.RestartContainer); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, 'p', gocui.ModNone, c.PauseContainer); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, 'P', gocui.ModNone, c.UnpauseContainer); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, 'K', gocui.ModNone, c.KillContainerPanel); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, 'b', gocui.ModNone, c.FileListPanel); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, 'D', gocui.ModNone, c.DiffPanel); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, 't', gocui.ModNone, c.TopPanel); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, 'n', gocui.ModNone, c.ConnectNetworkPanel); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, 'N', gocui.ModNone, c.DisconnectNetworkPanel); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, gocui.KeyCtrlP, gocui.ModNone, c.PruneContainers); err != nil {
+		panic(err)
+	}
 }
 
 func (c *ContainerList) selected() (*Container, error) {
@@ -172,6 +210,23 @@ func (c *ContainerList) selected() (*Container, error) {
 	return c.Containers[cy+oy], nil
 }
 
+// Select moves the cursor to the container, and reports whether it is in the list.
+func (c *ContainerList) Select(id string) bool {
+	v, err := c.View(c.name)
+	if err != nil {
+		return false
+	}
+
+	for i, container := range c.Containers {
+		if strings.HasPrefix(id, container.ID) || id == container.Name {
+			SelectLine(v, i)
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c *ContainerList) DetailContainer(g *gocui.Gui, v *gocui.View) error {
 	c.NextPanel = c.name
 
@@ -203,31 +258,120 @@ func (c *ContainerList) DetailContainer(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (c *ContainerList) RemoveContainer(g *gocui.Gui, v *gocui.View) error {
+	return c.bulkContainer(g, v, "remove", "container removing...", true, func(id string) error {
+		return c.Docker.RemoveContainer(docker.RemoveContainerOptions{ID: id})
+	})
+}
+
+func (c *ContainerList) StartContainer(g *gocui.Gui, v *gocui.View) error {
+	return c.bulkContainer(g, v, "start", "container starting...", false, c.Docker.StartContainerWithID)
+}
+
+func (c *ContainerList) StopContainer(g *gocui.Gui, v *gocui.View) error {
+	return c.bulkContainer(g, v, "stop", "container stopping...", false, func(id string) error {
+		return c.Docker.StopContainerWithID(id, c.StopTimeout)
+	})
+}
+
+// bulkContainer runs f for the marked containers or the selected container.
+// The confirmation is shown when confirm is true or there are multiple containers.
+func (c *ContainerList) bulkContainer(g *gocui.Gui, v *gocui.View, verb, message string, confirm bool, f func(id string) error) error {
 	c.NextPanel = c.name
 
-	container, err := c.selected()
+	ids, err := c.targets()
 	if err != nil {
 		c.ErrMessage(err.Error(), c.NextPanel)
 		return nil
 	}
 
-	c.ConfirmMessage("Are you sure you want to remove this container? (y/n)", func(g *gocui.Gui, v *gocui.View) error {
-		defer c.Refresh(g, v)
-		defer c.CloseConfirmMessage(g, v)
-		options := docker.RemoveContainerOptions{ID: container.ID}
+	do := func(g *gocui.Gui) error {
+		c.StateMessage(message)
+
+		g.Update(func(g *gocui.Gui) error {
+			defer c.Refresh(g, v)
+			defer c.CloseStateMessage()
+
+			err := BulkDo(ids, c.containerName, f)
+			for _, id := range ids {
+				delete(c.marked, id)
+			}
+
+			if err != nil {
+				c.ErrMessage(err.Error(), c.NextPanel)
+				return nil
+			}
+
+			c.SwitchPanel(c.NextPanel)
 
-		if err := c.Docker.RemoveContainer(options); err != nil {
-			c.ErrMessage(err.Error(), c.NextPanel)
 			return nil
-		}
+		})
+
+		return nil
+	}
 
+	if !confirm && len(ids) == 1 {
+		g.Update(do)
+		return nil
+	}
+
+	c.ConfirmMessage(fmt.Sprintf("Are you sure you want to %s %s? (y/n)", verb, confirmTarget(len(ids), "container")), func(g *gocui.Gui, v *gocui.View) error {
+		c.CloseConfirmMessage(g, v)
+		g.Update(do)
 		return nil
 	})
 
 	return nil
 }
 
-func (c *ContainerList) StartContainer(g *gocui.Gui, v *gocui.View) error {
+// targets returns the ids of the marked containers, or the selected container if nothing is marked.
+func (c *ContainerList) targets() ([]string, error) {
+	if marked := c.marked.Filter(c.ids()); len(marked) > 0 {
+		return marked, nil
+	}
+
+	container, err := c.selected()
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{container.ID}, nil
+}
+
+func (c *ContainerList) Mark(g *gocui.Gui, v *gocui.View) error {
+	container, err := c.selected()
+	if err != nil {
+		return nil
+	}
+
+	c.marked.Toggle(container.ID)
+	c.DisplayContainerList(v)
+
+	return CursorDown(g, v)
+}
+
+// MarkAll marks all containers shown with the filter, or unmarks them if all of them are marked.
+func (c *ContainerList) MarkAll(g *gocui.Gui, v *gocui.View) error {
+	c.marked.ToggleAll(c.ids())
+	c.DisplayContainerList(v)
+
+	return nil
+}
+
+func (c *ContainerList) RestartContainer(g *gocui.Gui, v *gocui.View) error {
+	return c.bulkContainer(g, v, "restart", "container restarting...", false, func(id string) error {
+		return c.Docker.RestartContainerWithID(id, c.StopTimeout)
+	})
+}
+
+func (c *ContainerList) PauseContainer(g *gocui.Gui, v *gocui.View) error {
+	return c.bulkContainer(g, v, "pause", "container pausing...", false, c.Docker.PauseContainerWithID)
+}
+
+func (c *ContainerList) UnpauseContainer(g *gocui.Gui, v *gocui.View) error {
+	return c.bulkContainer(g, v, "unpause", "container unpausing...", false, c.Docker.UnpauseContainerWithID)
+}
+
+func (c *ContainerList) KillContainerPanel(g *gocui.Gui, v *gocui.View) error {
 	c.NextPanel = c.name
 
 	container, err := c.selected()
@@ -236,14 +380,49 @@ func (c *ContainerList) StartContainer(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
+	c.Data = map[string]interface{}{
+		"Container": container.Name,
+		"Signal":    "SIGKILL",
+	}
+
+	maxX, maxY := c.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 6
+
+	c.ClosePanelName = KillContainerPanel
+	c.Items = c.NewKillContainerItems(x, y, w, h)
+
+	handlers := Handlers{
+		gocui.KeyEnter: c.KillContainer,
+	}
+
+	NewInput(c.Gui, KillContainerPanel, x, y, w, h, c.Items, c.Data, handlers)
+	return nil
+}
+
+func (c *ContainerList) KillContainer(g *gocui.Gui, v *gocui.View) error {
+	data, err := c.GetItemsToMap(c.Items)
+	if err != nil {
+		c.ClosePanel(g, v)
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
+
+	if data["Signal"] == "" {
+		return nil
+	}
+
 	g.Update(func(g *gocui.Gui) error {
-		c.StateMessage("container starting...")
+		c.ClosePanel(g, v)
+		c.StateMessage("container killing...")
 
 		g.Update(func(g *gocui.Gui) error {
 			defer c.Refresh(g, v)
 			defer c.CloseStateMessage()
 
-			if err := c.Docker.StartContainerWithID(container.ID); err != nil {
+			if err := c.Docker.KillContainerWithSignal(data["Container"], data["Signal"]); err != nil {
 				c.ErrMessage(err.Error(), c.NextPanel)
 				return nil
 			}
@@ -259,7 +438,7 @@ func (c *ContainerList) StartContainer(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
-func (c *ContainerList) StopContainer(g *gocui.Gui, v *gocui.View) error {
+func (c *ContainerList) FileListPanel(g *gocui.Gui, v *gocui.View) error {
 	c.NextPanel = c.name
 
 	container, err := c.selected()
@@ -268,25 +447,137 @@ func (c *ContainerList) StopContainer(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
-	g.Update(func(g *gocui.Gui) error {
-		c.StateMessage("container stopping...")
+	maxX, maxY := g.Size()
+	files := NewFileList(c.Gui, FileListPanel, maxX/7, 1, maxX-(maxX/7), maxY-4, container.ID, container.Name)
+	if err := files.SetView(g); err != nil {
+		c.ErrMessage(err.Error(), c.NextPanel)
+	}
 
-		g.Update(func(g *gocui.Gui) error {
-			defer c.CloseStateMessage()
-			defer c.Refresh(g, v)
+	return nil
+}
 
-			if err := c.Docker.StopContainerWithID(container.ID); err != nil {
-				c.ErrMessage(err.Error(), c.NextPanel)
-				return nil
-			}
+func (c *ContainerList) DiffPanel(g *gocui.Gui, v *gocui.View) error {
+	c.NextPanel = c.name
 
-			c.SwitchPanel(c.NextPanel)
+	container, err := c.selected()
+	if err != nil {
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
 
-			return nil
-		})
+	maxX, maxY := g.Size()
+	diff := NewDiff(c.Gui, DiffPanel, maxX/7, 1, maxX-(maxX/7), maxY-4, container.ID, container.Name)
+	if err := diff.SetView(g); err != nil {
+		c.ErrMessage(err.Error(), c.NextPanel)
+	}
+
+	return nil
+}
+
+func (c *ContainerList) TopPanel(g *gocui.Gui, v *gocui.View) error {
+	c.NextPanel = c.name
 
+	container, err := c.selected()
+	if err != nil {
+		c.ErrMessage(err.Error(), c.NextPanel)
 		return nil
-	})
+	}
+
+	maxX, maxY := g.Size()
+	top := NewTopList(c.Gui, TopPanel, maxX/7, 1, maxX-(maxX/7), maxY-4, container.ID, container.Name)
+	if err := top.SetView(g); err != nil {
+		c.ErrMessage(err.Error(), c.NextPanel)
+	}
+
+	return nil
+}
+
+func (c *ContainerList) ConnectNetworkPanel(g *gocui.Gui, v *gocui.View) error {
+	c.NextPanel = c.name
+
+	container, err := c.selected()
+	if err != nil {
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
+
+	c.Data = map[string]interface{}{
+		"Container": container.Name,
+	}
+
+	maxX, maxY := c.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 10
+
+	c.ClosePanelName = ConnectNetworkPanel
+	c.Items = NewConnectNetworkItems(x, y, w, h)
+
+	handlers := Handlers{
+		gocui.KeyEnter: c.ConnectNetwork,
+	}
+
+	NewInput(c.Gui, ConnectNetworkPanel, x, y, w, h, c.Items, c.Data, handlers)
+	return nil
+}
+
+func (c *ContainerList) ConnectNetwork(g *gocui.Gui, v *gocui.View) error {
+	return c.connectNetwork(g, v, c.Items, c.ClosePanel)
+}
+
+func (c *ContainerList) DisconnectNetworkPanel(g *gocui.Gui, v *gocui.View) error {
+	c.NextPanel = c.name
+
+	selected, err := c.selected()
+	if err != nil {
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
+
+	container, err := c.Docker.InspectContainer(selected.ID)
+	if err != nil {
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
+
+	c.Data = map[string]interface{}{
+		"Container": selected.Name,
+		"Force":     "n",
+	}
+
+	// fill the network if the container is connected to only one network
+	if networks := container.NetworkSettings.Networks; len(networks) == 1 {
+		for name := range networks {
+			c.Data["Network"] = name
+		}
+	}
+
+	maxX, maxY := c.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 8
+
+	c.ClosePanelName = DisconnectNetworkPanel
+	c.Items = NewDisconnectNetworkItems(x, y, w, h)
+
+	handlers := Handlers{
+		gocui.KeyEnter: c.DisconnectNetwork,
+	}
+
+	NewInput(c.Gui, DisconnectNetworkPanel, x, y, w, h, c.Items, c.Data, handlers)
+	return nil
+}
+
+func (c *ContainerList) DisconnectNetwork(g *gocui.Gui, v *gocui.View) error {
+	return c.disconnectNetwork(g, v, c.Items, c.ClosePanel)
+}
+
+func (c *ContainerList) PruneContainers(g *gocui.Gui, v *gocui.View) error {
+	c.NextPanel = c.name
+
+	c.ConfirmPrune("stopped containers", "containers", c.Docker.PruneStoppedContainers)
 
 	return nil
 }
@@ -500,6 +791,273 @@ func (c *ContainerList) RenameContainer(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
+func (c *ContainerList) LogsPanel(g *gocui.Gui, v *gocui.View) error {
+	c.NextPanel = c.name
+
+	container, err := c.selected()
+	if err != nil {
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
+
+	c.Data = map[string]interface{}{
+		"Container":  container.Name,
+		"Tail":       "100",
+		"Follow":     "y",
+		"Timestamps": "n",
+	}
+
+	maxX, maxY := c.Size()
+	x := maxX / 8
+	y := maxY / 4
+	w := maxX - x
+	h := maxY - y
+
+	c.ClosePanelName = ContainerLogsPanel
+	c.Items = c.NewLogsItems(x, y, w, h)
+
+	handlers := Handlers{
+		gocui.KeyEnter: c.ShowLogs,
+	}
+
+	NewInput(c.Gui, ContainerLogsPanel, x, y, w, h, c.Items, c.Data, handlers)
+	return nil
+}
+
+func (c *ContainerList) ShowLogs(g *gocui.Gui, v *gocui.View) error {
+	data, err := c.GetItemsToMap(c.Items)
+	if err != nil {
+		c.ClosePanel(g, v)
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
+
+	since, err := ParseSince(data["Since"])
+	if err != nil {
+		c.ClosePanel(g, v)
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
+
+	container, err := c.Docker.InspectContainer(data["Container"])
+	if err != nil {
+		c.ClosePanel(g, v)
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
+
+	tail := data["Tail"]
+	if tail == "" {
+		tail = "all"
+	}
+
+	options := docker.LogsOptions{
+		Container:   data["Container"],
+		Stdout:      true,
+		Stderr:      true,
+		Tail:        tail,
+		Since:       since,
+		Follow:      data["Follow"] == "y",
+		Timestamps:  data["Timestamps"] == "y",
+		RawTerminal: container.Config.Tty,
+	}
+
+	c.ClosePanel(g, v)
+
+	maxX, maxY := g.Size()
+	logs := NewLogs(c.Gui, LogsPanel, maxX/7, 1, maxX-(maxX/7), maxY-4, options)
+	if err := logs.SetView(g); err != nil {
+		panic(err)
+	}
+
+	return nil
+}
+
+func (c *ContainerList) ExecContainerPanel(g *gocui.Gui, v *gocui.View) error {
+	c.NextPanel = c.name
+
+	container, err := c.selected()
+	if err != nil {
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
+
+	c.Data = map[string]interface{}{
+		"Container": container.Name,
+		"Cmd":       "/bin/sh",
+	}
+
+	maxX, maxY := c.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 10
+
+	c.ClosePanelName = ExecContainerPanel
+	c.Items = c.NewExecContainerItems(x, y, w, h)
+
+	handlers := Handlers{
+		gocui.KeyEnter: c.ExecContainer,
+	}
+
+	NewInput(c.Gui, ExecContainerPanel, x, y, w, h, c.Items, c.Data, handlers)
+	return nil
+}
+
+func (c *ContainerList) ExecContainer(g *gocui.Gui, v *gocui.View) error {
+	data, err := c.GetItemsToMap(c.Items)
+	if err != nil {
+		c.ClosePanel(g, v)
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
+
+	cmd := strings.Fields(data["Cmd"])
+	if len(cmd) == 0 {
+		return nil
+	}
+
+	c.ClosePanel(g, v)
+
+	container, err := c.Docker.InspectContainer(data["Container"])
+	if err != nil {
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
+
+	if !container.State.Running {
+		c.ErrMessage("container is not running", c.NextPanel)
+		return nil
+	}
+
+	return c.Suspend(func() error {
+		return c.Docker.ExecWithTTY(container.ID, cmd)
+	})
+}
+
+func (c *ContainerList) CloneContainerPanel(g *gocui.Gui, v *gocui.View) error {
+	c.NextPanel = c.name
+
+	container, err := c.selected()
+	if err != nil {
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
+
+	config, err := c.Docker.NewContainerConfig(container.ID)
+	if err != nil {
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
+
+	c.Data = map[string]interface{}{
+		"ID": container.ID,
+	}
+	for k, v := range config {
+		c.Data[k] = v
+	}
+
+	maxX, maxY := c.Size()
+	x := maxX / 8
+	y := 1
+	w := maxX - x
+	h := maxY - 3
+
+	c.ClosePanelName = CloneContainerPanel
+	c.Items = NewCreateContainerItems(x, y, w, h)
+
+	handlers := Handlers{
+		gocui.KeyEnter: c.CloneContainer,
+	}
+
+	NewInput(c.Gui, CloneContainerPanel, x, y, w, h, c.Items, c.Data, handlers)
+	return nil
+}
+
+// CloneContainer runs a new container with the settings of the form.
+// If the name is not changed, the selected container is recreated.
+func (c *ContainerList) CloneContainer(g *gocui.Gui, v *gocui.View) error {
+	data, err := c.GetItemsToMap(c.Items)
+	if err != nil {
+		c.ClosePanel(g, v)
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
+
+	options, err := c.Docker.NewContainerOptions(data)
+	if err != nil {
+		c.ClosePanel(g, v)
+		c.ErrMessage(err.Error(), c.NextPanel)
+		return nil
+	}
+
+	id := c.Data["ID"].(string)
+
+	run := func(g *gocui.Gui, message string, f func() error) {
+		c.StateMessage(message)
+
+		g.Update(func(g *gocui.Gui) error {
+			defer c.Refresh(g, v)
+			defer c.CloseStateMessage()
+
+			if err := f(); err != nil {
+				c.ErrMessage(err.Error(), c.NextPanel)
+				return nil
+			}
+
+			c.SwitchPanel(c.NextPanel)
+
+			return nil
+		})
+	}
+
+	c.ClosePanel(g, v)
+
+	if options.Name == "" || options.Name != c.Data["Name"] {
+		g.Update(func(g *gocui.Gui) error {
+			run(g, "container cloning...", func() error {
+				return c.Docker.RunContainerWithOptions(options)
+			})
+			return nil
+		})
+
+		return nil
+	}
+
+	c.ConfirmMessage("Are you sure you want to recreate this container? (y/n)", func(g *gocui.Gui, v *gocui.View) error {
+		c.CloseConfirmMessage(g, v)
+
+		g.Update(func(g *gocui.Gui) error {
+			run(g, "container recreating...", func() error {
+				return c.Docker.RecreateContainerWithOptions(id, options, c.StopTimeout)
+			})
+			return nil
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
+func (c *ContainerList) StatsPanel(g *gocui.Gui, v *gocui.View) error {
+	c.NextPanel = c.name
+
+	var id string
+	if container, err := c.selected(); err == nil {
+		id = container.ID
+	}
+
+	maxX, maxY := g.Size()
+	stats := NewStatsList(c.Gui, StatsPanel, maxX/7, 1, maxX-(maxX/7), maxY-4, id)
+	if err := stats.SetView(g); err != nil {
+		stats.ClosePanel(g, v)
+		c.ErrMessage(err.Error(), c.NextPanel)
+	}
+
+	return nil
+}
+
 func (c *ContainerList) Refresh(g *gocui.Gui, v *gocui.View) error {
 	c.Update(func(g *gocui.Gui) error {
 		v, err := c.View(c.name)
@@ -519,7 +1077,10 @@ func (c *ContainerList) GetContainerList(v *gocui.View) {
 	v.Clear()
 	c.Containers = make([]*Container, 0)
 
+	var ids []string
 	for _, con := range c.Docker.Containers() {
+		ids = append(ids, con.ID[:12])
+
 		name := con.Names[0][1:]
 		if c.filter != "" {
 			if strings.Index(strings.ToLower(name), strings.ToLower(c.filter)) == -1 {
@@ -543,11 +1104,40 @@ func (c *ContainerList) GetContainerList(v *gocui.View) {
 		}
 
 		c.Containers = append(c.Containers, container)
+	}
+
+	c.marked.Retain(ids)
+	c.DisplayContainerList(v)
+}
 
-		common.OutputFormatedLine(v, container)
+func (c *ContainerList) DisplayContainerList(v *gocui.View) {
+	v.Clear()
+
+	for _, container := range c.Containers {
+		OutputMarkedLine(v, c.marked[container.ID], container)
 	}
 }
 
+// containerName returns the name of the container in the list, or id if it is not found.
+func (c *ContainerList) containerName(id string) string {
+	for _, container := range c.Containers {
+		if container.ID == id {
+			return container.Name
+		}
+	}
+
+	return id
+}
+
+func (c *ContainerList) ids() []string {
+	var ids []string
+	for _, container := range c.Containers {
+		ids = append(ids, container.ID)
+	}
+
+	return ids
+}
+
 func (c *ContainerList) Filter(g *gocui.Gui, lv *gocui.View) error {
 	c.NextPanel = c.name
 
@@ -615,3 +1205,33 @@ func (c *ContainerList) NewRenameContainerItems(ix, iy, iw, ih int) Items {
 
 	return NewItems(names, ix, iy, iw, ih, 12)
 }
+
+func (c *ContainerList) NewLogsItems(ix, iy, iw, ih int) Items {
+	names := []string{
+		"Container",
+		"Tail",
+		"Since",
+		"Follow",
+		"Timestamps",
+	}
+
+	return NewItems(names, ix, iy, iw, ih, 12)
+}
+
+func (c *ContainerList) NewExecContainerItems(ix, iy, iw, ih int) Items {
+	names := []string{
+		"Cmd",
+		"Container",
+	}
+
+	return NewItems(names, ix, iy, iw, ih, 12)
+}
+
+func (c *ContainerList) NewKillContainerItems(ix, iy, iw, ih int) Items {
+	names := []string{
+		"Container",
+		"Signal",
+	}
+
+	return NewItems(names, ix, iy, iw, ih, 12)
+}
@@ -0,0 +1,178 @@
+package panel
+
+import (
+	"fmt"
+
+	"github.com/jroimartin/gocui"
+	"github.com/skanehira/docui/common"
+	"github.com/skanehira/docui/docker"
+)
+
+type ContextList struct {
+	*Gui
+	Position
+	name     string
+	Contexts []*docker.Context
+	Warnings []error
+}
+
+type DockerContext struct {
+	Current     string `tag:"CURRENT" len:"min:0.1 max:0.1"`
+	Name        string `tag:"NAME" len:"min:0.1 max:0.2"`
+	Description string `tag:"DESCRIPTION" len:"min:0.1 max:0.3"`
+	Endpoint    string `tag:"DOCKER ENDPOINT" len:"min:0.1 max:0.4"`
+}
+
+func NewContextList(gui *Gui, name string, x, y, w, h int) *ContextList {
+	return &ContextList{
+		Gui:      gui,
+		name:     name,
+		Position: Position{x, y, w, h},
+	}
+}
+
+func (c *ContextList) Name() string {
+	return c.name
+}
+
+func (c *ContextList) SetView(g *gocui.Gui) error {
+	// set header panel
+	if v, err := g.SetView(ContextListHeaderPanel, c.x, c.y, c.w, c.h); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Wrap = true
+		v.Frame = true
+		v.Title = v.Name()
+		v.FgColor = gocui.AttrBold | gocui.ColorWhite
+		common.OutputFormatedHeader(v, &DockerContext{})
+
+		// the contexts which cannot be read are not listed
+		if len(c.Warnings) > 0 {
+			v.Title += fmt.Sprintf(" (skipped: %s)", docker.JoinErrors(c.Warnings))
+		}
+	}
+
+	// set scroll panel
+	v, err := g.SetView(c.name, c.x, c.y+1, c.w, c.h)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Wrap = true
+		v.FgColor = gocui.ColorGreen
+		v.SelBgColor = gocui.ColorWhite
+		v.SelFgColor = gocui.ColorBlack | gocui.AttrBold
+		v.SetOrigin(0, 0)
+		v.SetCursor(0, 0)
+	}
+
+	c.GetContextList(v)
+	c.SetKeyBinding()
+	c.SwitchPanel(c.name)
+
+	return nil
+}
+
+func (c *ContextList) SetKeyBinding() {
+	if err := c.SetKeybinding(c.name, 'j', gocui.ModNone, CursorDown); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, 'k', gocui.ModNone, CursorUp); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, gocui.KeyEnter, gocui.ModNone, c.SwitchContext); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, gocui.KeyEsc, gocui.ModNone, c.ClosePanel); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, 'q', gocui.ModNone, c.ClosePanel); err != nil {
+		panic(err)
+	}
+	if err := c.SetKeybinding(c.name, gocui.KeyCtrlQ, gocui.ModNone, c.quit); err != nil {
+		panic(err)
+	}
+}
+
+func (c *ContextList) Refresh(g *gocui.Gui, v *gocui.View) error {
+	return nil
+}
+
+func (c *ContextList) GetContextList(v *gocui.View) {
+	v.Clear()
+
+	for _, ctx := range c.Contexts {
+		var current string
+		if ctx.Name == c.Context {
+			current = "*"
+		}
+
+		common.OutputFormatedLine(v, &DockerContext{
+			Current:     current,
+			Name:        ctx.Name,
+			Description: ctx.Description,
+			Endpoint:    ctx.Config.Endpoint,
+		})
+	}
+}
+
+func (c *ContextList) selected() *docker.Context {
+	v, _ := c.View(c.name)
+	_, cy := v.Cursor()
+	_, oy := v.Origin()
+
+	index := oy + cy
+	if index >= len(c.Contexts) {
+		return nil
+	}
+
+	return c.Contexts[index]
+}
+
+func (c *ContextList) SwitchContext(g *gocui.Gui, v *gocui.View) error {
+	ctx := c.selected()
+	if ctx == nil {
+		return nil
+	}
+
+	g.Update(func(g *gocui.Gui) error {
+		c.ClosePanel(g, v)
+		c.StateMessage("context switching...")
+
+		g.Update(func(g *gocui.Gui) error {
+			c.CloseStateMessage()
+
+			if err := c.UseContext(ctx); err != nil {
+				c.ErrMessage(err.Error(), c.NextPanel)
+				return nil
+			}
+
+			c.RefreshAllPanel()
+
+			return nil
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
+func (c *ContextList) ClosePanel(g *gocui.Gui, v *gocui.View) error {
+	c.DeleteKeybindings(c.name)
+	if err := c.DeleteView(c.name); err != nil {
+		return err
+	}
+
+	if err := c.DeleteView(ContextListHeaderPanel); err != nil {
+		return err
+	}
+
+	c.SwitchPanel(c.NextPanel)
+
+	return nil
+}
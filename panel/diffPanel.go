@@ -0,0 +1,159 @@
+package panel
+
+import (
+	"fmt"
+	"sort"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/jroimartin/gocui"
+)
+
+// changeColors is the colors of the change kinds.
+var changeColors = map[docker.ChangeType]string{
+	docker.ChangeAdd:    "\x1b[32m",
+	docker.ChangeModify: "\x1b[33m",
+	docker.ChangeDelete: "\x1b[31m",
+}
+
+type Diff struct {
+	*Gui
+	Position
+	name          string
+	prev          string
+	container     string
+	containerName string
+	Changes       []docker.Change
+}
+
+func NewDiff(gui *Gui, name string, x, y, w, h int, container, containerName string) *Diff {
+	return &Diff{
+		Gui:           gui,
+		name:          name,
+		Position:      Position{x, y, w, h},
+		container:     container,
+		containerName: containerName,
+	}
+}
+
+func (d *Diff) Name() string {
+	return d.name
+}
+
+func (d *Diff) SetView(g *gocui.Gui) error {
+	changes, err := d.Docker.ContainerChanges(d.container)
+	if err != nil {
+		return err
+	}
+
+	d.Changes = changes
+	d.prev = d.NextPanel
+
+	v, err := g.SetView(d.name, d.x, d.y, d.w, d.h)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Wrap = true
+		v.SetOrigin(0, 0)
+		v.SetCursor(0, 0)
+	}
+
+	d.DisplayDiff(v)
+	d.SetKeyBinding()
+	d.SwitchPanel(d.name)
+
+	return nil
+}
+
+func (d *Diff) SetKeyBinding() {
+	if err := d.SetKeybinding(d.name, 'j', gocui.ModNone, CursorDown); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(d.name, 'k', gocui.ModNone, CursorUp); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(d.name, 'd', gocui.ModNone, PageDown); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(d.name, 'u', gocui.ModNone, PageUp); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(d.name, 'c', gocui.ModNone, d.CommitContainerPanel); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(d.name, gocui.KeyCtrlR, gocui.ModNone, d.Refresh); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(d.name, gocui.KeyEsc, gocui.ModNone, d.CloseDiffPanel); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(d.name, 'q', gocui.ModNone, d.CloseDiffPanel); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(d.name, gocui.KeyCtrlQ, gocui.ModNone, d.quit); err != nil {
+		panic(err)
+	}
+}
+
+func (d *Diff) Refresh(g *gocui.Gui, v *gocui.View) error {
+	changes, err := d.Docker.ContainerChanges(d.container)
+	if err != nil {
+		d.ErrMessage(err.Error(), d.name)
+		return nil
+	}
+
+	d.Changes = changes
+
+	v, err = g.View(d.name)
+	if err != nil {
+		return err
+	}
+
+	d.DisplayDiff(v)
+
+	return nil
+}
+
+// DisplayDiff shows the changes sorted by path with the color of the kind.
+func (d *Diff) DisplayDiff(v *gocui.View) {
+	v.Clear()
+
+	sort.Slice(d.Changes, func(i, j int) bool {
+		return d.Changes[i].Path < d.Changes[j].Path
+	})
+
+	count := make(map[docker.ChangeType]int)
+	for _, change := range d.Changes {
+		count[change.Kind]++
+		fmt.Fprintf(v, "%s%s\x1b[0m\n", changeColors[change.Kind], change.String())
+	}
+
+	v.Title = fmt.Sprintf("%s %s (added: %d, changed: %d, deleted: %d)", d.name, d.containerName,
+		count[docker.ChangeAdd], count[docker.ChangeModify], count[docker.ChangeDelete])
+
+	if len(d.Changes) == 0 {
+		fmt.Fprintln(v, "no changes")
+	}
+}
+
+// CommitContainerPanel closes the diff and opens the commit panel of the container.
+func (d *Diff) CommitContainerPanel(g *gocui.Gui, v *gocui.View) error {
+	if err := d.CloseDiffPanel(g, v); err != nil {
+		return err
+	}
+
+	return d.Panels[ContainerListPanel].(*ContainerList).CommitContainerPanel(g, v)
+}
+
+func (d *Diff) CloseDiffPanel(g *gocui.Gui, v *gocui.View) error {
+	d.DeleteKeybindings(d.name)
+	if err := d.DeleteView(d.name); err != nil {
+		return err
+	}
+
+	d.NextPanel = d.prev
+	d.SwitchPanel(d.NextPanel)
+
+	return nil
+}
@@ -0,0 +1,477 @@
+package panel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+	"github.com/skanehira/docui/common"
+	"github.com/skanehira/docui/docker"
+)
+
+// diskUsageTypes is the order of the rows of the disk usage.
+var diskUsageTypes = []string{"Images", "Containers", "Local Volumes", "Build Cache"}
+
+type DiskUsageList struct {
+	*Gui
+	Position
+	name    string
+	prev    string
+	Usage   []*DiskUsage
+	items   [][]*DiskUsageItem
+	sizes   [][]int64
+	current int
+}
+
+type DiskUsage struct {
+	Type        string `tag:"TYPE" len:"min:0.1 max:0.2"`
+	Total       string `tag:"TOTAL" len:"min:0.1 max:0.1"`
+	Active      string `tag:"ACTIVE" len:"min:0.1 max:0.1"`
+	Size        string `tag:"SIZE" len:"min:0.1 max:0.2"`
+	Reclaimable string `tag:"RECLAIMABLE" len:"min:0.1 max:0.3"`
+}
+
+type DiskUsageItem struct {
+	Name    string `tag:"NAME" len:"min:0.1 max:0.5"`
+	Size    string `tag:"SIZE" len:"min:0.1 max:0.15"`
+	State   string `tag:"STATE" len:"min:0.1 max:0.15"`
+	Created string `tag:"CREATED" len:"min:0.1 max:0.2"`
+}
+
+// diskUsageItem is the item with the size to sort.
+type diskUsageItem struct {
+	*DiskUsageItem
+	size int64
+}
+
+func NewDiskUsageList(gui *Gui, name string, x, y, w, h int) *DiskUsageList {
+	return &DiskUsageList{
+		Gui:      gui,
+		name:     name,
+		Position: Position{x, y, w, h},
+	}
+}
+
+func (d *DiskUsageList) Name() string {
+	return d.name
+}
+
+func (d *DiskUsageList) SetView(g *gocui.Gui) error {
+	usage, err := d.Docker.DiskUsage()
+	if err != nil {
+		return err
+	}
+
+	d.SetDiskUsage(usage)
+	d.prev = d.NextPanel
+
+	summaryH := d.y + len(diskUsageTypes) + 2
+
+	// set header panel
+	if v, err := g.SetView(DiskUsageHeaderPanel, d.x, d.y, d.w, summaryH); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Wrap = true
+		v.Frame = true
+		v.Title = v.Name()
+		v.FgColor = gocui.AttrBold | gocui.ColorWhite
+		common.OutputFormatedHeader(v, &DiskUsage{})
+	}
+
+	// set scroll panel
+	v, err := g.SetView(d.name, d.x, d.y+1, d.w, summaryH)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Wrap = true
+		v.FgColor = gocui.ColorGreen
+		v.SelBgColor = gocui.ColorWhite
+		v.SelFgColor = gocui.ColorBlack | gocui.AttrBold
+		v.SetOrigin(0, 0)
+		v.SetCursor(0, 0)
+	}
+
+	// set item header panel
+	if v, err := g.SetView(DiskUsageItemHeaderPanel, d.x, summaryH+1, d.w, d.h); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Wrap = true
+		v.Frame = true
+		v.FgColor = gocui.AttrBold | gocui.ColorWhite
+		common.OutputFormatedHeader(v, &DiskUsageItem{})
+	}
+
+	// set item scroll panel
+	if v, err := g.SetView(DiskUsageItemPanel, d.x, summaryH+2, d.w, d.h); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Wrap = true
+		v.FgColor = gocui.ColorCyan
+		v.SelBgColor = gocui.ColorWhite
+		v.SelFgColor = gocui.ColorBlack | gocui.AttrBold
+		v.SetOrigin(0, 0)
+		v.SetCursor(0, 0)
+	}
+
+	d.Display(g)
+	d.SetKeyBinding()
+	d.SwitchPanel(d.name)
+
+	return nil
+}
+
+func (d *DiskUsageList) SetKeyBinding() {
+	if err := d.SetKeybinding(d.name, 'j', gocui.ModNone, d.moveCursor(CursorDown)); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(d.name, 'k', gocui.ModNone, d.moveCursor(CursorUp)); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(d.name, gocui.KeyEnter, gocui.ModNone, d.ShowItems); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(d.name, 'p', gocui.ModNone, d.Prune); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(d.name, gocui.KeyCtrlR, gocui.ModNone, d.Refresh); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(d.name, gocui.KeyEsc, gocui.ModNone, d.CloseDiskUsagePanel); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(d.name, 'q', gocui.ModNone, d.CloseDiskUsagePanel); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(d.name, gocui.KeyCtrlQ, gocui.ModNone, d.quit); err != nil {
+		panic(err)
+	}
+
+	if err := d.SetKeybinding(DiskUsageItemPanel, 'j', gocui.ModNone, CursorDown); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(DiskUsageItemPanel, 'k', gocui.ModNone, CursorUp); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(DiskUsageItemPanel, 'd', gocui.ModNone, PageDown); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(DiskUsageItemPanel, 'u', gocui.ModNone, PageUp); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(DiskUsageItemPanel, 'p', gocui.ModNone, d.Prune); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(DiskUsageItemPanel, gocui.KeyEsc, gocui.ModNone, d.HideItems); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(DiskUsageItemPanel, 'q', gocui.ModNone, d.HideItems); err != nil {
+		panic(err)
+	}
+	if err := d.SetKeybinding(DiskUsageItemPanel, gocui.KeyCtrlQ, gocui.ModNone, d.quit); err != nil {
+		panic(err)
+	}
+}
+
+// moveCursor moves the cursor and shows the items of the selected type.
+func (d *DiskUsageList) moveCursor(f func(g *gocui.Gui, v *gocui.View) error) func(g *gocui.Gui, v *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		if err := f(g, v); err != nil {
+			return err
+		}
+
+		_, cy := v.Cursor()
+		_, oy := v.Origin()
+		if index := cy + oy; index < len(d.Usage) {
+			d.current = index
+		}
+
+		d.DisplayItems(g)
+		return nil
+	}
+}
+
+func (d *DiskUsageList) Refresh(g *gocui.Gui, v *gocui.View) error {
+	usage, err := d.Docker.DiskUsage()
+	if err != nil {
+		d.ErrMessage(err.Error(), d.name)
+		return nil
+	}
+
+	d.SetDiskUsage(usage)
+	d.Display(g)
+
+	return nil
+}
+
+// SetDiskUsage summarizes the usage and sorts the items of each type by size.
+func (d *DiskUsageList) SetDiskUsage(usage *docker.DiskUsage) {
+	d.Usage = make([]*DiskUsage, len(diskUsageTypes))
+	d.items = make([][]*DiskUsageItem, len(diskUsageTypes))
+
+	items := make([][]diskUsageItem, len(diskUsageTypes))
+	total := make([]int, len(diskUsageTypes))
+	active := make([]int, len(diskUsageTypes))
+	size := make([]int64, len(diskUsageTypes))
+	reclaimable := make([]int64, len(diskUsageTypes))
+
+	// images
+	size[0] = usage.LayersSize
+	var used int64
+	for _, image := range usage.Images {
+		total[0]++
+
+		name := image.ID
+		if strings.HasPrefix(name, "sha256:") {
+			name = name[7:19]
+		}
+		for _, tag := range image.RepoTags {
+			if tag != "<none>:<none>" {
+				name = tag
+				break
+			}
+		}
+
+		state := "unused"
+		if image.Containers > 0 {
+			active[0]++
+			state = fmt.Sprintf("%d containers", image.Containers)
+
+			// like docker system df, the layers unique to the images in use are not reclaimable
+			if image.SharedSize != -1 {
+				used += image.Size - image.SharedSize
+			}
+		}
+
+		items[0] = append(items[0], diskUsageItem{&DiskUsageItem{
+			Name:    name,
+			Size:    ParseSizeToString(image.Size),
+			State:   state,
+			Created: ParseDateToString(image.Created),
+		}, image.Size})
+	}
+
+	reclaimable[0] = usage.LayersSize - used
+
+	// containers
+	for _, container := range usage.Containers {
+		total[1]++
+		size[1] += container.SizeRw
+
+		if container.State == "running" || container.State == "paused" || container.State == "restarting" {
+			active[1]++
+		} else {
+			reclaimable[1] += container.SizeRw
+		}
+
+		name := container.ID[:12]
+		if len(container.Names) > 0 {
+			name = strings.TrimPrefix(container.Names[0], "/")
+		}
+
+		items[1] = append(items[1], diskUsageItem{&DiskUsageItem{
+			Name:    name,
+			Size:    ParseSizeToString(container.SizeRw),
+			State:   container.State,
+			Created: ParseDateToString(container.Created),
+		}, container.SizeRw})
+	}
+
+	// volumes
+	for _, volume := range usage.Volumes {
+		total[2]++
+
+		state := "unused"
+		if volume.UsageData.RefCount > 0 {
+			active[2]++
+			state = fmt.Sprintf("%d containers", volume.UsageData.RefCount)
+		}
+
+		volumeSize := "N/A"
+		if volume.UsageData.Size != -1 {
+			size[2] += volume.UsageData.Size
+			volumeSize = ParseSizeToString(volume.UsageData.Size)
+
+			if volume.UsageData.RefCount == 0 {
+				reclaimable[2] += volume.UsageData.Size
+			}
+		}
+
+		items[2] = append(items[2], diskUsageItem{&DiskUsageItem{
+			Name:    volume.Name,
+			Size:    volumeSize,
+			State:   state,
+			Created: ParseDateToString(volume.CreatedAt.Unix()),
+		}, volume.UsageData.Size})
+	}
+
+	// build cache
+	for _, cache := range usage.BuildCache {
+		total[3]++
+
+		state := "unused"
+		switch {
+		case cache.InUse:
+			active[3]++
+			state = "in use"
+		case cache.Shared:
+			state = "shared"
+		default:
+			reclaimable[3] += cache.Size
+		}
+
+		if !cache.Shared {
+			size[3] += cache.Size
+		}
+
+		name := cache.ID
+		if len(name) > 12 {
+			name = name[:12]
+		}
+		if cache.Description != "" {
+			name += " " + cache.Description
+		}
+
+		items[3] = append(items[3], diskUsageItem{&DiskUsageItem{
+			Name:    name,
+			Size:    ParseSizeToString(cache.Size),
+			State:   state,
+			Created: ParseDateToString(cache.CreatedAt.Unix()),
+		}, cache.Size})
+	}
+
+	for i, name := range diskUsageTypes {
+		percent := 0
+		if size[i] > 0 {
+			percent = int(reclaimable[i] * 100 / size[i])
+		}
+
+		d.Usage[i] = &DiskUsage{
+			Type:        name,
+			Total:       fmt.Sprint(total[i]),
+			Active:      fmt.Sprint(active[i]),
+			Size:        ParseSizeToString(size[i]),
+			Reclaimable: fmt.Sprintf("%s (%d%%)", ParseSizeToString(reclaimable[i]), percent),
+		}
+
+		sort.SliceStable(items[i], func(a, b int) bool {
+			return items[i][a].size > items[i][b].size
+		})
+
+		for _, item := range items[i] {
+			d.items[i] = append(d.items[i], item.DiskUsageItem)
+		}
+	}
+}
+
+func (d *DiskUsageList) Display(g *gocui.Gui) {
+	v, err := g.View(d.name)
+	if err != nil {
+		return
+	}
+
+	v.Clear()
+	for _, usage := range d.Usage {
+		common.OutputFormatedLine(v, usage)
+	}
+
+	d.DisplayItems(g)
+}
+
+// DisplayItems shows the items of the selected type, the largest first.
+func (d *DiskUsageList) DisplayItems(g *gocui.Gui) {
+	v, err := g.View(DiskUsageItemPanel)
+	if err != nil {
+		return
+	}
+
+	v.Clear()
+	v.SetOrigin(0, 0)
+	v.SetCursor(0, 0)
+
+	if header, err := g.View(DiskUsageItemHeaderPanel); err == nil {
+		header.Title = fmt.Sprintf("%s (largest first)", strings.ToLower(diskUsageTypes[d.current]))
+	}
+
+	for _, item := range d.items[d.current] {
+		common.OutputFormatedLine(v, item)
+	}
+}
+
+// ShowItems moves to the items of the selected type.
+func (d *DiskUsageList) ShowItems(g *gocui.Gui, v *gocui.View) error {
+	if len(d.items[d.current]) == 0 {
+		return nil
+	}
+
+	d.SwitchPanel(DiskUsageItemPanel)
+	return nil
+}
+
+// HideItems goes back to the disk usage.
+func (d *DiskUsageList) HideItems(g *gocui.Gui, v *gocui.View) error {
+	d.SwitchPanel(d.name)
+	return nil
+}
+
+// Prune prunes the selected type and refreshes the disk usage.
+func (d *DiskUsageList) Prune(g *gocui.Gui, v *gocui.View) error {
+	d.NextPanel = v.Name()
+
+	var (
+		target string
+		kind   string
+		prune  func() (*docker.PruneReport, error)
+	)
+
+	switch d.current {
+	case 0:
+		target, kind = "unused images", "images"
+		prune = func() (*docker.PruneReport, error) {
+			return d.Docker.PruneImagesWithFilters(true, nil)
+		}
+	case 1:
+		target, kind, prune = "stopped containers", "containers", d.Docker.PruneStoppedContainers
+	case 2:
+		target, kind, prune = "unused volumes", "volumes", d.Docker.PruneUnusedVolumes
+	case 3:
+		// the reclaimable space counts all build cache not in use, so all of it is removed
+		target, kind = "all build cache not in use", "build caches"
+		prune = func() (*docker.PruneReport, error) {
+			return d.Docker.PruneBuildCache(true)
+		}
+	}
+
+	d.ConfirmPrune(target, kind, func() (*docker.PruneReport, error) {
+		defer d.Refresh(g, v)
+		return prune()
+	})
+
+	return nil
+}
+
+func (d *DiskUsageList) CloseDiskUsagePanel(g *gocui.Gui, v *gocui.View) error {
+	d.DeleteKeybindings(d.name)
+	d.DeleteKeybindings(DiskUsageItemPanel)
+
+	for _, name := range []string{d.name, DiskUsageHeaderPanel, DiskUsageItemPanel, DiskUsageItemHeaderPanel} {
+		if err := d.DeleteView(name); err != nil {
+			return err
+		}
+	}
+
+	d.NextPanel = d.prev
+	d.SwitchPanel(d.NextPanel)
+
+	return nil
+}
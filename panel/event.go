@@ -0,0 +1,167 @@
+package panel
+
+import (
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/jroimartin/gocui"
+)
+
+const (
+	// refresh all list panels in case of missing events
+	fallbackRefreshInterval = 60 * time.Second
+	// wait for the following events to refresh the panel once
+	eventRefreshDelay = 300 * time.Millisecond
+	// the max wait time to reconnect to the event stream
+	maxEventRetryWait = 30 * time.Second
+)
+
+// eventPanels is the panels to refresh on each event type.
+var eventPanels = map[string][]string{
+	"container": {ContainerListPanel},
+	"image":     {ImageListPanel},
+	"volume":    {VolumeListPanel},
+	"network":   {NetworkListPanel},
+	"service":   {ServiceListPanel},
+	"node":      {NodeListPanel},
+	"secret":    {SecretListPanel},
+	"config":    {ConfigListPanel},
+}
+
+// listPanels is the panels refreshed periodically.
+var listPanels = []string{
+	ImageListPanel,
+	ContainerListPanel,
+	VolumeListPanel,
+	NetworkListPanel,
+	ServiceListPanel,
+	NodeListPanel,
+	SecretListPanel,
+	ConfigListPanel,
+}
+
+// WatchEvents subscribes the docker events and refreshes the panels related to them.
+// The previous subscription is stopped, so call it again after changing the docker client.
+func (gui *Gui) WatchEvents() {
+	gui.StopEvents()
+
+	stop := make(chan struct{})
+	gui.stopEvents = stop
+
+	dirty := make(chan string, 100)
+
+	go gui.listenEvents(gui.Docker.Client, dirty, stop)
+	go gui.refreshPanels(dirty, stop)
+}
+
+// StopEvents stops the subscription of the docker events.
+func (gui *Gui) StopEvents() {
+	if gui.stopEvents != nil {
+		close(gui.stopEvents)
+		gui.stopEvents = nil
+	}
+}
+
+func (gui *Gui) listenEvents(client *docker.Client, dirty chan<- string, stop <-chan struct{}) {
+	wait := time.Second
+
+	markDirty := func(names []string) bool {
+		for _, name := range names {
+			select {
+			case dirty <- name:
+			case <-stop:
+				return false
+			}
+		}
+		return true
+	}
+
+	for {
+		events := make(chan *docker.APIEvents, 100)
+
+		if err := client.AddEventListener(events); err == nil {
+		loop:
+			for {
+				select {
+				case <-stop:
+					client.RemoveEventListener(events)
+					return
+				case event, ok := <-events:
+					// the listener is closed when the stream dropped
+					if !ok {
+						break loop
+					}
+
+					wait = time.Second
+
+					gui.Update(func(g *gocui.Gui) error {
+						gui.EventList.Add(g, event)
+						return nil
+					})
+
+					if !markDirty(eventPanels[event.Type]) {
+						client.RemoveEventListener(events)
+						return
+					}
+				}
+			}
+		}
+
+		// reconnect with backoff
+		select {
+		case <-stop:
+			return
+		case <-time.After(wait):
+		}
+
+		wait *= 2
+		if wait > maxEventRetryWait {
+			wait = maxEventRetryWait
+		}
+
+		// the events may be missed while reconnecting
+		if !markDirty(listPanels) {
+			return
+		}
+	}
+}
+
+func (gui *Gui) refreshPanels(dirty <-chan string, stop <-chan struct{}) {
+	ticker := time.NewTicker(fallbackRefreshInterval)
+	defer ticker.Stop()
+
+	pending := make(map[string]bool)
+	var flush <-chan time.Time
+
+	for {
+		select {
+		case <-stop:
+			return
+		case name := <-dirty:
+			pending[name] = true
+			if flush == nil {
+				flush = time.After(eventRefreshDelay)
+			}
+		case <-ticker.C:
+			for _, name := range listPanels {
+				pending[name] = true
+			}
+			if flush == nil {
+				flush = time.After(0)
+			}
+		case <-flush:
+			names := pending
+			pending = make(map[string]bool)
+			flush = nil
+
+			gui.Update(func(g *gocui.Gui) error {
+				for name := range names {
+					if panel, ok := gui.Panels[name]; ok {
+						panel.Refresh(g, nil)
+					}
+				}
+				return nil
+			})
+		}
+	}
+}
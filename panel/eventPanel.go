@@ -0,0 +1,339 @@
+package panel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/jroimartin/gocui"
+	"github.com/skanehira/docui/common"
+)
+
+const (
+	// number of events kept in the log
+	maxEvents = 1000
+)
+
+type EventList struct {
+	*Gui
+	Position
+	name         string
+	Events       []*docker.APIEvents
+	filtered     []*docker.APIEvents
+	typeFilter   string
+	actionFilter string
+	editing      *string
+}
+
+type Event struct {
+	Time       string `tag:"TIME" len:"min:0.1 max:0.15"`
+	Type       string `tag:"TYPE" len:"min:0.1 max:0.1"`
+	Action     string `tag:"ACTION" len:"min:0.1 max:0.1"`
+	Actor      string `tag:"ACTOR" len:"min:0.1 max:0.25"`
+	Attributes string `tag:"ATTRIBUTES" len:"min:0.1 max:0.4"`
+}
+
+func NewEventList(gui *Gui, name string) *EventList {
+	return &EventList{
+		Gui:  gui,
+		name: name,
+	}
+}
+
+func (e *EventList) Name() string {
+	return e.name
+}
+
+func (e *EventList) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+	switch {
+	case ch != 0 && mod == 0:
+		v.EditWrite(ch)
+	case key == gocui.KeySpace:
+		v.EditWrite(' ')
+	case key == gocui.KeyBackspace || key == gocui.KeyBackspace2:
+		v.EditDelete(true)
+	case key == gocui.KeyArrowLeft:
+		v.MoveCursor(-1, 0, false)
+		return
+	case key == gocui.KeyArrowRight:
+		v.MoveCursor(+1, 0, false)
+		return
+	}
+
+	if e.editing != nil {
+		*e.editing = ReadLine(v, nil)
+	}
+
+	if v, err := e.View(e.name); err == nil {
+		e.GetEventList(v)
+		SelectLine(v, len(e.filtered)-1)
+	}
+}
+
+// SetView pops up the event log.
+func (e *EventList) SetView(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+	e.Position = Position{maxX / 7, 1, maxX - (maxX / 7), maxY - 4}
+
+	// set header panel
+	if v, err := g.SetView(EventListHeaderPanel, e.x, e.y, e.w, e.h); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Wrap = true
+		v.Frame = true
+		v.FgColor = gocui.AttrBold | gocui.ColorWhite
+		common.OutputFormatedHeader(v, &Event{})
+	}
+
+	// set scroll panel
+	v, err := g.SetView(e.name, e.x, e.y+1, e.w, e.h)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Wrap = false
+		v.FgColor = gocui.ColorGreen
+		v.SelBgColor = gocui.ColorWhite
+		v.SelFgColor = gocui.ColorBlack | gocui.AttrBold
+	}
+
+	e.GetEventList(v)
+	SelectLine(v, len(e.filtered)-1)
+
+	e.SetKeyBinding()
+	e.SwitchPanel(e.name)
+
+	return nil
+}
+
+func (e *EventList) SetKeyBinding() {
+	if err := e.SetKeybinding(e.name, 'j', gocui.ModNone, CursorDown); err != nil {
+		panic(err)
+	}
+	if err := e.SetKeybinding(e.name, 'k', gocui.ModNone, CursorUp); err != nil {
+		panic(err)
+	}
+	if err := e.SetKeybinding(e.name, 'd', gocui.ModNone, PageDown); err != nil {
+		panic(err)
+	}
+	if err := e.SetKeybinding(e.name, 'u', gocui.ModNone, PageUp); err != nil {
+		panic(err)
+	}
+	if err := e.SetKeybinding(e.name, 't', gocui.ModNone, e.Filter(&e.typeFilter)); err != nil {
+		panic(err)
+	}
+	if err := e.SetKeybinding(e.name, 'a', gocui.ModNone, e.Filter(&e.actionFilter)); err != nil {
+		panic(err)
+	}
+	if err := e.SetKeybinding(e.name, gocui.KeyEnter, gocui.ModNone, e.Jump); err != nil {
+		panic(err)
+	}
+	if err := e.SetKeybinding(e.name, gocui.KeyEsc, gocui.ModNone, e.ClosePanel); err != nil {
+		panic(err)
+	}
+	if err := e.SetKeybinding(e.name, 'q', gocui.ModNone, e.ClosePanel); err != nil {
+		panic(err)
+	}
+	if err := e.SetKeybinding(e.name, gocui.KeyCtrlQ, gocui.ModNone, e.quit); err != nil {
+		panic(err)
+	}
+}
+
+func (e *EventList) Refresh(g *gocui.Gui, v *gocui.View) error {
+	return nil
+}
+
+// Add appends the event to the log and shows it if the log is opened.
+func (e *EventList) Add(g *gocui.Gui, event *docker.APIEvents) {
+	// the events may arrive out of order
+	index := sort.Search(len(e.Events), func(i int) bool {
+		return e.Events[i].TimeNano > event.TimeNano
+	})
+
+	e.Events = append(e.Events, nil)
+	copy(e.Events[index+1:], e.Events[index:])
+	e.Events[index] = event
+
+	if len(e.Events) > maxEvents {
+		e.Events = e.Events[len(e.Events)-maxEvents:]
+	}
+
+	v, err := g.View(e.name)
+	if err != nil {
+		return
+	}
+
+	// keep following the latest event when the cursor is on the last line
+	_, cy := v.Cursor()
+	_, oy := v.Origin()
+	follow := cy+oy >= len(e.filtered)-1
+
+	e.GetEventList(v)
+
+	if follow {
+		SelectLine(v, len(e.filtered)-1)
+	}
+}
+
+func (e *EventList) GetEventList(v *gocui.View) {
+	v.Clear()
+	e.filtered = make([]*docker.APIEvents, 0)
+
+	if header, err := e.View(EventListHeaderPanel); err == nil {
+		header.Title = fmt.Sprintf("%s (type:%s action:%s)", EventListHeaderPanel, e.typeFilter, e.actionFilter)
+	}
+
+	for _, event := range e.Events {
+		if !strings.Contains(strings.ToLower(event.Type), strings.ToLower(e.typeFilter)) {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(event.Action), strings.ToLower(e.actionFilter)) {
+			continue
+		}
+
+		e.filtered = append(e.filtered, event)
+
+		common.OutputFormatedLine(v, &Event{
+			Time:       time.Unix(0, event.TimeNano).Format("2006/01/02 15:04:05"),
+			Type:       event.Type,
+			Action:     event.Action,
+			Actor:      parseActor(event.Actor),
+			Attributes: parseAttributes(event.Actor.Attributes),
+		})
+	}
+}
+
+func parseActor(actor docker.APIActor) string {
+	id := actor.ID
+	if len(id) == 64 {
+		id = id[:12]
+	}
+
+	if name := actor.Attributes["name"]; name != "" && name != actor.ID {
+		return fmt.Sprintf("%s (%s)", name, id)
+	}
+
+	return id
+}
+
+func parseAttributes(attributes map[string]string) string {
+	var keys []string
+	for key := range attributes {
+		if key != "name" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var result []string
+	for _, key := range keys {
+		result = append(result, fmt.Sprintf("%s=%s", key, attributes[key]))
+	}
+
+	return strings.Join(result, " ")
+}
+
+func (e *EventList) selected() *docker.APIEvents {
+	v, _ := e.View(e.name)
+	_, cy := v.Cursor()
+	_, oy := v.Origin()
+
+	index := oy + cy
+	if index >= len(e.filtered) {
+		return nil
+	}
+
+	return e.filtered[index]
+}
+
+// Filter returns the handler to edit the filter.
+func (e *EventList) Filter(filter *string) func(g *gocui.Gui, v *gocui.View) error {
+	return func(g *gocui.Gui, ev *gocui.View) error {
+		e.NextPanel = e.name
+		e.editing = filter
+
+		isReset := false
+		closePanel := func(g *gocui.Gui, v *gocui.View) error {
+			if isReset {
+				*filter = ""
+			} else {
+				*filter = ReadLine(v, nil)
+			}
+			e.editing = nil
+
+			e.GetEventList(ev)
+			SelectLine(ev, len(e.filtered)-1)
+
+			if err := g.DeleteView(v.Name()); err != nil {
+				panic(err)
+			}
+
+			g.DeleteKeybindings(v.Name())
+			e.SwitchPanel(e.name)
+			return nil
+		}
+
+		reset := func(g *gocui.Gui, v *gocui.View) error {
+			isReset = true
+			return closePanel(g, v)
+		}
+
+		if err := e.NewFilterPanel(e, reset, closePanel); err != nil {
+			panic(err)
+		}
+
+		return nil
+	}
+}
+
+// Jump selects the container or the image of the event in the list panel.
+func (e *EventList) Jump(g *gocui.Gui, v *gocui.View) error {
+	event := e.selected()
+	if event == nil {
+		return nil
+	}
+
+	var found bool
+	var next string
+
+	switch event.Type {
+	case "container":
+		next = ContainerListPanel
+		found = e.Panels[next].(*ContainerList).Select(event.Actor.ID)
+	case "image":
+		next = ImageListPanel
+		found = e.Panels[next].(*ImageList).Select(event.Actor.ID)
+	default:
+		return nil
+	}
+
+	if !found {
+		e.ErrMessage(fmt.Sprintf("%s %s is not in the list", event.Type, parseActor(event.Actor)), e.name)
+		return nil
+	}
+
+	e.ClosePanel(g, v)
+	e.FocusPanel(next)
+
+	return nil
+}
+
+func (e *EventList) ClosePanel(g *gocui.Gui, v *gocui.View) error {
+	e.DeleteKeybindings(e.name)
+
+	for _, name := range []string{e.name, EventListHeaderPanel} {
+		if err := e.DeleteView(name); err != nil {
+			panic(err)
+		}
+	}
+
+	e.SwitchPanel(e.NextPanel)
+
+	return nil
+}
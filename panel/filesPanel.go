@@ -0,0 +1,438 @@
+package panel
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"path"
+
+	"github.com/jroimartin/gocui"
+	"github.com/skanehira/docui/common"
+	"github.com/skanehira/docui/docker"
+)
+
+// fileViewLimit is the max size of the file to show.
+const fileViewLimit = 1 << 20
+
+type FileList struct {
+	*Gui
+	Position
+	name           string
+	prev           string
+	container      string
+	containerName  string
+	dir            string
+	Files          []*tar.Header
+	Data           map[string]interface{}
+	ClosePanelName string
+	Items          Items
+}
+
+type File struct {
+	Mode     string `tag:"MODE" len:"min:0.1 max:0.1"`
+	Size     string `tag:"SIZE" len:"min:0.1 max:0.1"`
+	Modified string `tag:"MODIFIED" len:"min:0.1 max:0.2"`
+	Name     string `tag:"NAME" len:"min:0.1 max:0.6"`
+}
+
+func NewFileList(gui *Gui, name string, x, y, w, h int, container, containerName string) *FileList {
+	return &FileList{
+		Gui:           gui,
+		name:          name,
+		Position:      Position{x, y, w, h},
+		container:     container,
+		containerName: containerName,
+		dir:           "/",
+		Data:          make(map[string]interface{}),
+		Items:         Items{},
+	}
+}
+
+func (f *FileList) Name() string {
+	return f.name
+}
+
+func (f *FileList) SetView(g *gocui.Gui) error {
+	files, err := f.Docker.ListContainerDir(f.container, f.dir)
+	if err != nil {
+		return err
+	}
+
+	f.Files = files
+	f.prev = f.NextPanel
+
+	// set header panel
+	if v, err := g.SetView(FileListHeaderPanel, f.x, f.y, f.w, f.h); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Wrap = true
+		v.Frame = true
+		v.FgColor = gocui.AttrBold | gocui.ColorWhite
+		common.OutputFormatedHeader(v, &File{})
+	}
+
+	// set scroll panel
+	v, err := g.SetView(f.name, f.x, f.y+1, f.w, f.h)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Wrap = true
+		v.FgColor = gocui.ColorGreen
+		v.SelBgColor = gocui.ColorWhite
+		v.SelFgColor = gocui.ColorBlack | gocui.AttrBold
+		v.SetOrigin(0, 0)
+		v.SetCursor(0, 0)
+	}
+
+	f.GetFileList(v)
+	f.SetKeyBinding()
+	f.SwitchPanel(f.name)
+
+	return nil
+}
+
+func (f *FileList) SetKeyBinding() {
+	if err := f.SetKeybinding(f.name, 'j', gocui.ModNone, CursorDown); err != nil {
+		panic(err)
+	}
+	if err := f.SetKeybinding(f.name, 'k', gocui.ModNone, CursorUp); err != nil {
+		panic(err)
+	}
+	if err := f.SetKeybinding(f.name, gocui.KeyEnter, gocui.ModNone, f.Open); err != nil {
+		panic(err)
+	}
+	if err := f.SetKeybinding(f.name, 'l', gocui.ModNone, f.Open); err != nil {
+		panic(err)
+	}
+	if err := f.SetKeybinding(f.name, 'h', gocui.ModNone, f.Parent); err != nil {
+		panic(err)
+	}
+	if err := f.SetKeybinding(f.name, gocui.KeyBackspace, gocui.ModNone, f.Parent); err != nil {
+		panic(err)
+	}
+	if err := f.SetKeybinding(f.name, gocui.KeyBackspace2, gocui.ModNone, f.Parent); err != nil {
+		panic(err)
+	}
+	if err := f.SetKeybinding(f.name, 'd', gocui.ModNone, f.CopyFromContainerPanel); err != nil {
+		panic(err)
+	}
+	if err := f.SetKeybinding(f.name, 'u', gocui.ModNone, f.CopyToContainerPanel); err != nil {
+		panic(err)
+	}
+	if err := f.SetKeybinding(f.name, gocui.KeyCtrlR, gocui.ModNone, f.Refresh); err != nil {
+		panic(err)
+	}
+	if err := f.SetKeybinding(f.name, gocui.KeyEsc, gocui.ModNone, f.CloseFileListPanel); err != nil {
+		panic(err)
+	}
+	if err := f.SetKeybinding(f.name, 'q', gocui.ModNone, f.CloseFileListPanel); err != nil {
+		panic(err)
+	}
+	if err := f.SetKeybinding(f.name, gocui.KeyCtrlQ, gocui.ModNone, f.quit); err != nil {
+		panic(err)
+	}
+}
+
+func (f *FileList) Refresh(g *gocui.Gui, v *gocui.View) error {
+	if err := f.ChangeDir(g, f.dir); err != nil {
+		f.ErrMessage(err.Error(), f.name)
+	}
+
+	return nil
+}
+
+func (f *FileList) GetFileList(v *gocui.View) {
+	v.Clear()
+
+	if header, err := f.View(FileListHeaderPanel); err == nil {
+		header.Title = fmt.Sprintf("%s %s:%s", FileListHeaderPanel, f.containerName, f.dir)
+	}
+
+	for _, file := range f.Files {
+		name := file.Name
+		switch file.Typeflag {
+		case tar.TypeDir:
+			name += "/"
+		case tar.TypeSymlink:
+			name += " -> " + file.Linkname
+		}
+
+		common.OutputFormatedLine(v, &File{
+			Mode:     file.FileInfo().Mode().String(),
+			Size:     ParseSizeToString(file.Size),
+			Modified: ParseDateToString(file.ModTime.Unix()),
+			Name:     name,
+		})
+	}
+}
+
+func (f *FileList) selected() *tar.Header {
+	v, _ := f.View(f.name)
+	_, cy := v.Cursor()
+	_, oy := v.Origin()
+
+	index := oy + cy
+	if index >= len(f.Files) {
+		return nil
+	}
+
+	return f.Files[index]
+}
+
+// ChangeDir shows the entries of the directory in the container.
+func (f *FileList) ChangeDir(g *gocui.Gui, dir string) error {
+	files, err := f.Docker.ListContainerDir(f.container, dir)
+	if err != nil {
+		return err
+	}
+
+	f.dir = path.Clean(dir)
+	f.Files = files
+
+	v, err := g.View(f.name)
+	if err != nil {
+		return err
+	}
+
+	v.SetOrigin(0, 0)
+	v.SetCursor(0, 0)
+	f.GetFileList(v)
+
+	return nil
+}
+
+// Open changes the directory or shows the selected file.
+func (f *FileList) Open(g *gocui.Gui, v *gocui.View) error {
+	file := f.selected()
+	if file == nil {
+		return nil
+	}
+
+	p := path.Join(f.dir, file.Name)
+
+	var err error
+	switch file.Typeflag {
+	case tar.TypeDir:
+		err = f.ChangeDir(g, p)
+	case tar.TypeSymlink:
+		target := file.Linkname
+		if !path.IsAbs(target) {
+			target = path.Join(f.dir, target)
+		}
+
+		if err = f.ChangeDir(g, target); err == docker.ErrNotDirectory {
+			err = f.ViewFile(g, v, target)
+		}
+	default:
+		err = f.ViewFile(g, v, p)
+	}
+
+	if err != nil {
+		f.ErrMessage(err.Error(), f.name)
+	}
+
+	return nil
+}
+
+// Parent changes the directory to the parent directory and selects the directory where it was.
+func (f *FileList) Parent(g *gocui.Gui, v *gocui.View) error {
+	if f.dir == "/" {
+		return nil
+	}
+
+	prev := path.Base(f.dir)
+	if err := f.ChangeDir(g, path.Dir(f.dir)); err != nil {
+		f.ErrMessage(err.Error(), f.name)
+		return nil
+	}
+
+	for i, file := range f.Files {
+		if file.Name == prev {
+			SelectLine(v, i)
+			break
+		}
+	}
+
+	return nil
+}
+
+// ViewFile shows the text file in the container.
+func (f *FileList) ViewFile(g *gocui.Gui, v *gocui.View, file string) error {
+	data, truncated, err := f.Docker.ReadContainerFile(f.container, file, fileViewLimit)
+	if err != nil {
+		return err
+	}
+
+	if bytes.IndexByte(data, 0) != -1 {
+		return fmt.Errorf("%s is a binary file, please copy it to the host", file)
+	}
+
+	f.PopupDetailPanel(g, v)
+
+	v, err = g.View(DetailPanel)
+	if err != nil {
+		panic(err)
+	}
+
+	v.Clear()
+	v.SetOrigin(0, 0)
+	v.SetCursor(0, 0)
+
+	v.Title = file
+	if truncated {
+		v.Title += fmt.Sprintf(" (first %s)", ParseSizeToString(fileViewLimit))
+	}
+
+	fmt.Fprint(v, string(data))
+
+	return nil
+}
+
+func (f *FileList) CopyFromContainerPanel(g *gocui.Gui, v *gocui.View) error {
+	f.NextPanel = f.name
+
+	src := f.dir
+	if file := f.selected(); file != nil {
+		src = path.Join(f.dir, file.Name)
+	}
+
+	f.Data = map[string]interface{}{
+		"Source":      src,
+		"Destination": ".",
+	}
+
+	maxX, maxY := f.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 6
+
+	f.ClosePanelName = CopyFromContainerPanel
+	f.Items = NewItems([]string{"Source", "Destination"}, x, y, w, h, 12)
+
+	handlers := Handlers{
+		gocui.KeyEnter: f.CopyFromContainer,
+	}
+
+	NewInput(f.Gui, CopyFromContainerPanel, x, y, w, h, f.Items, f.Data, handlers)
+	return nil
+}
+
+func (f *FileList) CopyFromContainer(g *gocui.Gui, v *gocui.View) error {
+	data, err := f.GetItemsToMap(f.Items)
+	if err != nil {
+		f.ClosePanel(g, v)
+		f.ErrMessage(err.Error(), f.NextPanel)
+		return nil
+	}
+
+	if data["Source"] == "" || data["Destination"] == "" {
+		return nil
+	}
+
+	g.Update(func(g *gocui.Gui) error {
+		f.ClosePanel(g, v)
+		f.StateMessage("copying...")
+
+		g.Update(func(g *gocui.Gui) error {
+			defer f.CloseStateMessage()
+
+			if err := f.Docker.CopyFromContainer(f.container, data["Source"], data["Destination"]); err != nil {
+				f.ErrMessage(err.Error(), f.NextPanel)
+				return nil
+			}
+
+			f.SwitchPanel(f.NextPanel)
+
+			return nil
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
+func (f *FileList) CopyToContainerPanel(g *gocui.Gui, v *gocui.View) error {
+	f.NextPanel = f.name
+
+	f.Data = map[string]interface{}{
+		"Destination": f.dir,
+	}
+
+	maxX, maxY := f.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 6
+
+	f.ClosePanelName = CopyToContainerPanel
+	f.Items = NewItems([]string{"Source", "Destination"}, x, y, w, h, 12)
+
+	handlers := Handlers{
+		gocui.KeyEnter: f.CopyToContainer,
+	}
+
+	NewInput(f.Gui, CopyToContainerPanel, x, y, w, h, f.Items, f.Data, handlers)
+	return nil
+}
+
+func (f *FileList) CopyToContainer(g *gocui.Gui, v *gocui.View) error {
+	data, err := f.GetItemsToMap(f.Items)
+	if err != nil {
+		f.ClosePanel(g, v)
+		f.ErrMessage(err.Error(), f.NextPanel)
+		return nil
+	}
+
+	if data["Source"] == "" || data["Destination"] == "" {
+		return nil
+	}
+
+	g.Update(func(g *gocui.Gui) error {
+		f.ClosePanel(g, v)
+		f.StateMessage("copying...")
+
+		g.Update(func(g *gocui.Gui) error {
+			defer f.Refresh(g, v)
+			defer f.CloseStateMessage()
+
+			if err := f.Docker.CopyToContainer(f.container, data["Source"], data["Destination"]); err != nil {
+				f.ErrMessage(err.Error(), f.NextPanel)
+				return nil
+			}
+
+			f.SwitchPanel(f.NextPanel)
+
+			return nil
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
+func (f *FileList) ClosePanel(g *gocui.Gui, v *gocui.View) error {
+	return f.Panels[f.ClosePanelName].(*Input).ClosePanel(g, v)
+}
+
+func (f *FileList) CloseFileListPanel(g *gocui.Gui, v *gocui.View) error {
+	f.DeleteKeybindings(f.name)
+
+	for _, name := range []string{f.name, FileListHeaderPanel} {
+		if err := f.DeleteView(name); err != nil {
+			return err
+		}
+	}
+
+	f.NextPanel = f.prev
+	f.SwitchPanel(f.NextPanel)
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/skanehira/docui/docker"
 
 	"github.com/jroimartin/gocui"
+	"github.com/nsf/termbox-go"
 )
 
 const (
@@ -40,15 +41,75 @@ const (
 	FilterPanel                  = "filter"
 	NetworkListPanel             = "network list scroll"
 	NetworkListHeaderPanel       = "network list"
+	ContextListPanel             = "context list scroll"
+	ContextListHeaderPanel       = "context list"
+	ContainerLogsPanel           = "container logs"
+	LogsPanel                    = "logs"
+	ExecContainerPanel           = "exec container"
+	StatsPanel                   = "stats scroll"
+	StatsHeaderPanel             = "stats"
+	StatsGraphPanel              = "stats graph"
+	EventListPanel               = "event list scroll"
+	EventListHeaderPanel         = "event list"
+	ProgressPanel                = "progress"
+	RegistryListPanel            = "registry list scroll"
+	RegistryListHeaderPanel      = "registry list"
+	LoginPanel                   = "login"
+	PushImagePanel               = "push image"
+	TagImagePanel                = "tag image"
+	BuildImagePanel              = "build image"
+	BuildPanel                   = "build"
+	RunContainerPanel            = "run container"
+	CloneContainerPanel          = "clone container"
+	KillContainerPanel           = "kill container"
+	HistoryPanel                 = "history scroll"
+	HistoryHeaderPanel           = "history"
+	HistoryDetailPanel           = "layer"
+	FileListPanel                = "files scroll"
+	FileListHeaderPanel          = "files"
+	CopyFromContainerPanel       = "copy from container"
+	CopyToContainerPanel         = "copy to container"
+	DiffPanel                    = "diff"
+	TopPanel                     = "top scroll"
+	TopHeaderPanel               = "top"
+	CreateNetworkPanel           = "create network"
+	ConnectNetworkPanel          = "connect network"
+	DisconnectNetworkPanel       = "disconnect network"
+	ResultMessagePanel           = "result"
+	PruneImagesPanel             = "prune images"
+	DiskUsagePanel               = "disk usage scroll"
+	DiskUsageHeaderPanel         = "disk usage"
+	DiskUsageItemPanel           = "disk usage items scroll"
+	DiskUsageItemHeaderPanel     = "disk usage items"
+	ServiceListPanel             = "service list scroll"
+	ServiceListHeaderPanel       = "service list"
+	ScaleServicePanel            = "scale service"
+	UpdateServicePanel           = "update service"
+	NodeListPanel                = "node list scroll"
+	NodeListHeaderPanel          = "node list"
+	SecretListPanel              = "secret list scroll"
+	SecretListHeaderPanel        = "secret list"
+	CreateSecretPanel            = "create secret"
+	ConfigListPanel              = "config list scroll"
+	ConfigListHeaderPanel        = "config list"
+	CreateConfigPanel            = "create config"
+	TaskListPanel                = "tasks scroll"
+	TaskListHeaderPanel          = "tasks"
 )
 
 type Gui struct {
 	*gocui.Gui
-	Docker     *docker.Docker
-	Panels     map[string]Panel
-	PanelNames []string
-	NextPanel  string
-	active     int
+	Docker       *docker.Docker
+	ClientConfig *docker.ClientConfig
+	Context      string
+	StopTimeout  uint
+	Panels       map[string]Panel
+	PanelNames   []string
+	NextPanel    string
+	active       int
+	swarm        bool
+	stopEvents   chan struct{}
+	EventList    *EventList
 }
 
 type Panel interface {
@@ -63,7 +124,17 @@ type Position struct {
 	w, h int
 }
 
-func New(mode gocui.OutputMode) *Gui {
+func New(mode gocui.OutputMode, config *docker.ClientConfig, context string, stopTimeout uint) *Gui {
+	ctx, err := docker.FindContext(context, config)
+	if err != nil {
+		panic(err)
+	}
+
+	d, err := docker.NewDocker(ctx.Config)
+	if err != nil {
+		panic(err)
+	}
+
 	g, err := gocui.NewGui(mode)
 	if err != nil {
 		panic(err)
@@ -74,22 +145,55 @@ func New(mode gocui.OutputMode) *Gui {
 	g.SelFgColor = gocui.AttrBold
 	g.InputEsc = true
 
-	d := docker.NewDocker()
-
 	gui := &Gui{
-		Gui:        g,
-		Docker:     d,
-		Panels:     make(map[string]Panel),
-		PanelNames: []string{},
-		NextPanel:  ImageListPanel,
-		active:     0,
+		Gui:          g,
+		Docker:       d,
+		ClientConfig: config,
+		Context:      ctx.Name,
+		StopTimeout:  stopTimeout,
+		Panels:       make(map[string]Panel),
+		PanelNames:   []string{},
+		NextPanel:    ImageListPanel,
+		active:       0,
 	}
 
+	gui.EventList = NewEventList(gui, EventListPanel)
+
 	gui.init()
+	gui.WatchEvents()
 
 	return gui
 }
 
+// Suspend releases the terminal to run f such as a shell in the container, and resumes gocui.
+// f runs in the keybinding handler, so the main loop and its input poller keep running
+// and the poller waits for the input until termbox is initialized again.
+func (gui *Gui) Suspend(f func() error) error {
+	termbox.Close()
+	ferr := f()
+
+	if err := termbox.Init(); err != nil {
+		return err
+	}
+
+	// termbox resets the input mode on close
+	mode := termbox.InputAlt
+	if gui.InputEsc {
+		mode = termbox.InputEsc
+	}
+	if gui.Mouse {
+		mode |= termbox.InputMouse
+	}
+	termbox.SetInputMode(mode)
+
+	if ferr != nil {
+		gui.ErrMessage(ferr.Error(), gui.NextPanel)
+	}
+
+	gui.RefreshAllPanel()
+	return nil
+}
+
 func (gui *Gui) AddPanelNames(panel Panel) {
 	name := panel.Name()
 	gui.PanelNames = append(gui.PanelNames, name)
@@ -111,6 +215,18 @@ func (gui *Gui) SetKeyBindingToPanel(panel string) {
 	if err := gui.SetKeybinding(panel, gocui.KeyCtrlO, gocui.ModNone, gui.DockerInfo); err != nil {
 		panic(err)
 	}
+	if err := gui.SetKeybinding(panel, gocui.KeyCtrlX, gocui.ModNone, gui.ContextListPanel); err != nil {
+		panic(err)
+	}
+	if err := gui.SetKeybinding(panel, gocui.KeyCtrlE, gocui.ModNone, gui.EventListPanel); err != nil {
+		panic(err)
+	}
+	if err := gui.SetKeybinding(panel, gocui.KeyCtrlG, gocui.ModNone, gui.RegistryListPanel); err != nil {
+		panic(err)
+	}
+	if err := gui.SetKeybinding(panel, gocui.KeyCtrlU, gocui.ModNone, gui.DiskUsagePanel); err != nil {
+		panic(err)
+	}
 }
 
 func (gui *Gui) SetGlobalKeyBinding() {
@@ -132,6 +248,116 @@ func (gui *Gui) DockerInfo(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
+func (gui *Gui) ContextListPanel(g *gocui.Gui, v *gocui.View) error {
+	gui.NextPanel = g.CurrentView().Name()
+
+	maxX, maxY := g.Size()
+	x := maxX / 8
+	y := maxY / 4
+	w := maxX - x
+	h := maxY - y
+
+	contexts, warnings, err := docker.Contexts(gui.ClientConfig)
+	if err != nil {
+		gui.ErrMessage(err.Error(), gui.NextPanel)
+		return nil
+	}
+
+	panel := NewContextList(gui, ContextListPanel, x, y, w, h)
+	panel.Contexts = contexts
+	panel.Warnings = warnings
+
+	return panel.SetView(g)
+}
+
+func (gui *Gui) RegistryListPanel(g *gocui.Gui, v *gocui.View) error {
+	gui.NextPanel = g.CurrentView().Name()
+
+	maxX, maxY := g.Size()
+	x := maxX / 8
+	y := maxY / 4
+	w := maxX - x
+	h := maxY - y
+
+	registries, err := docker.Registries()
+	if err != nil {
+		gui.ErrMessage(err.Error(), gui.NextPanel)
+		return nil
+	}
+
+	panel := NewRegistryList(gui, RegistryListPanel, x, y, w, h)
+	panel.Registries = registries
+	panel.prev = gui.NextPanel
+
+	return panel.SetView(g)
+}
+
+func (gui *Gui) DiskUsagePanel(g *gocui.Gui, v *gocui.View) error {
+	gui.NextPanel = g.CurrentView().Name()
+
+	maxX, maxY := g.Size()
+	x := maxX / 8
+	y := maxY / 8
+	w := maxX - x
+	h := maxY - y
+
+	panel := NewDiskUsageList(gui, DiskUsagePanel, x, y, w, h)
+	if err := panel.SetView(g); err != nil {
+		gui.ErrMessage(err.Error(), gui.NextPanel)
+	}
+
+	return nil
+}
+
+func (gui *Gui) EventListPanel(g *gocui.Gui, v *gocui.View) error {
+	gui.NextPanel = g.CurrentView().Name()
+	return gui.EventList.SetView(g)
+}
+
+// UseContext replaces the docker client with the one connecting to ctx.
+func (gui *Gui) UseContext(ctx *docker.Context) error {
+	d, err := docker.NewDocker(ctx.Config)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Ping(); err != nil {
+		return err
+	}
+
+	gui.Docker = d
+	gui.Context = ctx.Name
+	gui.WatchEvents()
+
+	if d.IsSwarmManager() != gui.swarm {
+		gui.relayout()
+	}
+
+	return nil
+}
+
+// relayout deletes all views and creates the panels again,
+// because the swarm panels are shown or hidden when the context is switched.
+func (gui *Gui) relayout() {
+	var names []string
+	for _, v := range gui.Views() {
+		names = append(names, v.Name())
+	}
+
+	for _, name := range names {
+		gui.DeleteKeybindings(name)
+		gui.DeleteView(name)
+	}
+	gui.DeleteKeybindings("")
+
+	gui.Panels = make(map[string]Panel)
+	gui.PanelNames = []string{}
+	gui.NextPanel = ImageListPanel
+	gui.active = 0
+
+	gui.init()
+}
+
 func (gui *Gui) nextPanel(g *gocui.Gui, v *gocui.View) error {
 	nextIndex := (gui.active + 1) % len(gui.PanelNames)
 	name := gui.PanelNames[nextIndex]
@@ -158,6 +384,18 @@ func (gui *Gui) prePanel(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
+// FocusPanel switches to the list panel and keeps the order of Tab.
+func (gui *Gui) FocusPanel(name string) {
+	for i, panelName := range gui.PanelNames {
+		if panelName == name {
+			gui.active = i
+		}
+	}
+
+	gui.NextPanel = name
+	gui.SwitchPanel(name)
+}
+
 func (gui *Gui) quit(g *gocui.Gui, v *gocui.View) error {
 	return gocui.ErrQuit
 }
@@ -166,10 +404,25 @@ func (gui *Gui) init() {
 	maxX, maxY := gui.Size()
 	topY := maxY / 4
 
-	gui.StorePanels(NewImageList(gui, ImageListPanel, 0, 0, maxX-1, topY-1))
-	gui.StorePanels(NewContainerList(gui, ContainerListPanel, 0, topY, maxX-1, topY*2-1))
-	gui.StorePanels(NewVolumeList(gui, VolumeListPanel, 0, topY*2, maxX-1, topY*3-1))
-	gui.StorePanels(NewNetworkList(gui, NetworkListPanel, 0, topY*3, maxX-1, maxY-3))
+	// the swarm panels are shown on the right side only for the swarm manager
+	listW := maxX - 1
+	gui.swarm = gui.Docker.IsSwarmManager()
+	if gui.swarm {
+		listW = maxX/2 - 1
+	}
+
+	gui.StorePanels(NewImageList(gui, ImageListPanel, 0, 0, listW, topY-1))
+	gui.StorePanels(NewContainerList(gui, ContainerListPanel, 0, topY, listW, topY*2-1))
+	gui.StorePanels(NewVolumeList(gui, VolumeListPanel, 0, topY*2, listW, topY*3-1))
+	gui.StorePanels(NewNetworkList(gui, NetworkListPanel, 0, topY*3, listW, maxY-3))
+
+	if gui.swarm {
+		gui.StorePanels(NewServiceList(gui, ServiceListPanel, listW+1, 0, maxX-1, topY-1))
+		gui.StorePanels(NewNodeList(gui, NodeListPanel, listW+1, topY, maxX-1, topY*2-1))
+		gui.StorePanels(NewSecretList(gui, SecretListPanel, listW+1, topY*2, maxX-1, topY*3-1))
+		gui.StorePanels(NewConfigList(gui, ConfigListPanel, listW+1, topY*3, maxX-1, maxY-3))
+	}
+
 	gui.StorePanels(NewNavigate(gui, NavigatePanel, 0, maxY-3, maxX-1, maxY))
 
 	for _, panel := range gui.Panels {
@@ -189,6 +442,10 @@ func (gui *Gui) StorePanels(panel Panel) {
 		DetailPanel:        true,
 		VolumeListPanel:    true,
 		NetworkListPanel:   true,
+		ServiceListPanel:   true,
+		NodeListPanel:      true,
+		SecretListPanel:    true,
+		ConfigListPanel:    true,
 	}
 
 	if storeTarget[panel.Name()] {
@@ -239,6 +496,38 @@ func (gui *Gui) ErrMessage(message string, nextPanel string) {
 	})
 }
 
+// ResultMessage shows the result of the operation.
+func (gui *Gui) ResultMessage(message string, nextPanel string) {
+	gui.Update(func(g *gocui.Gui) error {
+		gui.NextPanel = nextPanel
+		maxX, maxY := gui.Size()
+
+		x := maxX / 5
+		y := maxY / 3
+		v, err := gui.SetView(ResultMessagePanel, x, y, maxX-x, y+6)
+		if err != nil {
+			if err != gocui.ErrUnknownView {
+				panic(err)
+			}
+			v.Wrap = true
+			v.Title = v.Name()
+			fmt.Fprint(v, message)
+			gui.SwitchPanel(v.Name())
+		}
+
+		if err := gui.SetKeybinding(v.Name(), gocui.KeyEnter, gocui.ModNone, gui.CloseMessage); err != nil {
+			panic(err)
+		}
+		if err := gui.SetKeybinding(v.Name(), 'j', gocui.ModNone, CursorDown); err != nil {
+			panic(err)
+		}
+		if err := gui.SetKeybinding(v.Name(), 'k', gocui.ModNone, CursorUp); err != nil {
+			panic(err)
+		}
+		return nil
+	})
+}
+
 func (gui *Gui) CloseMessage(g *gocui.Gui, v *gocui.View) error {
 	if err := g.DeleteView(v.Name()); err != nil {
 		panic(err)
@@ -491,6 +780,23 @@ func PageUp(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
+// SelectLine moves the cursor to the line, scrolling the view if needed.
+func SelectLine(v *gocui.View, line int) {
+	if line < 0 {
+		line = 0
+	}
+
+	_, h := v.Size()
+
+	oy := 0
+	if line >= h {
+		oy = line - h + 1
+	}
+
+	v.SetOrigin(0, oy)
+	v.SetCursor(0, line-oy)
+}
+
 func ReadLine(v *gocui.View, y *int) string {
 	if y == nil {
 		_, ny := v.Cursor()
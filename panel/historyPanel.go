@@ -0,0 +1,246 @@
+package panel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/jroimartin/gocui"
+	"github.com/skanehira/docui/common"
+)
+
+// highlightLayers is the number of the largest layers to highlight.
+const highlightLayers = 3
+
+type HistoryList struct {
+	*Gui
+	Position
+	name    string
+	image   string
+	History []docker.ImageHistory
+	largest map[int]bool
+}
+
+type Layer struct {
+	ID        string `tag:"IMAGE" len:"min:0.1 max:0.1"`
+	Created   string `tag:"CREATED" len:"min:0.1 max:0.2"`
+	CreatedBy string `tag:"CREATED BY" len:"min:0.1 max:0.4"`
+	Size      string `tag:"SIZE" len:"min:0.1 max:0.1"`
+	Comment   string `tag:"COMMENT" len:"min:0.1 max:0.2"`
+}
+
+func NewHistoryList(gui *Gui, name string, x, y, w, h int, image string) *HistoryList {
+	return &HistoryList{
+		Gui:      gui,
+		name:     name,
+		Position: Position{x, y, w, h},
+		image:    image,
+	}
+}
+
+func (h *HistoryList) Name() string {
+	return h.name
+}
+
+func (h *HistoryList) SetView(g *gocui.Gui) error {
+	history, err := h.Docker.ImageHistory(h.image)
+	if err != nil {
+		return err
+	}
+
+	h.History = history
+	h.largest = LargestLayers(history, highlightLayers)
+
+	detailY := h.h - 5
+
+	// set header panel
+	if v, err := g.SetView(HistoryHeaderPanel, h.x, h.y, h.w, detailY-1); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Wrap = true
+		v.Frame = true
+		v.Title = fmt.Sprintf("%s %s (%s)", v.Name(), h.image, ParseSizeToString(h.totalSize()))
+		v.FgColor = gocui.AttrBold | gocui.ColorWhite
+		common.OutputFormatedHeader(v, &Layer{})
+	}
+
+	// set scroll panel
+	v, err := g.SetView(h.name, h.x, h.y+1, h.w, detailY-1)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Wrap = true
+		v.FgColor = gocui.ColorGreen
+		v.SelBgColor = gocui.ColorWhite
+		v.SelFgColor = gocui.ColorBlack | gocui.AttrBold
+		v.SetOrigin(0, 0)
+		v.SetCursor(0, 0)
+	}
+
+	// set detail panel
+	if v, err := g.SetView(HistoryDetailPanel, h.x, detailY, h.w, h.h); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Wrap = true
+		v.Frame = true
+		v.FgColor = gocui.ColorCyan
+	}
+
+	h.GetHistoryList(v)
+	h.SetKeyBinding()
+	h.SwitchPanel(h.name)
+	h.DisplayDetail(g)
+
+	return nil
+}
+
+func (h *HistoryList) SetKeyBinding() {
+	if err := h.SetKeybinding(h.name, 'j', gocui.ModNone, h.moveCursor(CursorDown)); err != nil {
+		panic(err)
+	}
+	if err := h.SetKeybinding(h.name, 'k', gocui.ModNone, h.moveCursor(CursorUp)); err != nil {
+		panic(err)
+	}
+	if err := h.SetKeybinding(h.name, gocui.KeyEsc, gocui.ModNone, h.ClosePanel); err != nil {
+		panic(err)
+	}
+	if err := h.SetKeybinding(h.name, 'q', gocui.ModNone, h.ClosePanel); err != nil {
+		panic(err)
+	}
+	if err := h.SetKeybinding(h.name, gocui.KeyCtrlQ, gocui.ModNone, h.quit); err != nil {
+		panic(err)
+	}
+}
+
+// moveCursor moves the cursor and shows the detail of the selected layer.
+func (h *HistoryList) moveCursor(f func(g *gocui.Gui, v *gocui.View) error) func(g *gocui.Gui, v *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		if err := f(g, v); err != nil {
+			return err
+		}
+
+		h.DisplayDetail(g)
+		return nil
+	}
+}
+
+func (h *HistoryList) Refresh(g *gocui.Gui, v *gocui.View) error {
+	return nil
+}
+
+func (h *HistoryList) GetHistoryList(v *gocui.View) {
+	v.Clear()
+
+	for i, layer := range h.History {
+		id := layer.ID
+		if strings.HasPrefix(id, "sha256:") {
+			id = id[7:19]
+		}
+
+		if h.largest[i] {
+			fmt.Fprint(v, "\x1b[31m")
+		}
+
+		common.OutputFormatedLine(v, &Layer{
+			ID:        id,
+			Created:   ParseDateToString(layer.Created),
+			CreatedBy: strings.Join(strings.Fields(layer.CreatedBy), " "),
+			Size:      ParseSizeToString(layer.Size),
+			Comment:   layer.Comment,
+		})
+
+		if h.largest[i] {
+			fmt.Fprint(v, "\x1b[0m")
+		}
+	}
+}
+
+func (h *HistoryList) selected() *docker.ImageHistory {
+	v, _ := h.View(h.name)
+	_, cy := v.Cursor()
+	_, oy := v.Origin()
+
+	index := oy + cy
+	if index >= len(h.History) {
+		return nil
+	}
+
+	return &h.History[index]
+}
+
+// DisplayDetail shows the full command of the selected layer.
+func (h *HistoryList) DisplayDetail(g *gocui.Gui) {
+	v, err := g.View(HistoryDetailPanel)
+	if err != nil {
+		return
+	}
+
+	v.Clear()
+
+	layer := h.selected()
+	if layer == nil {
+		return
+	}
+
+	v.Title = fmt.Sprintf("%s (%s)", HistoryDetailPanel, ParseSizeToString(layer.Size))
+	fmt.Fprintln(v, strings.Join(strings.Fields(layer.CreatedBy), " "))
+	if layer.Comment != "" {
+		fmt.Fprintln(v, layer.Comment)
+	}
+}
+
+func (h *HistoryList) totalSize() int64 {
+	var size int64
+	for _, layer := range h.History {
+		size += layer.Size
+	}
+
+	return size
+}
+
+func (h *HistoryList) ClosePanel(g *gocui.Gui, v *gocui.View) error {
+	h.DeleteKeybindings(h.name)
+
+	for _, name := range []string{h.name, HistoryHeaderPanel, HistoryDetailPanel} {
+		if err := h.DeleteView(name); err != nil {
+			panic(err)
+		}
+	}
+
+	h.SwitchPanel(h.NextPanel)
+
+	return nil
+}
+
+// LargestLayers returns the indexes of the n largest layers which are not empty.
+func LargestLayers(history []docker.ImageHistory, n int) map[int]bool {
+	indexes := make([]int, 0, len(history))
+	for i, layer := range history {
+		if layer.Size > 0 {
+			indexes = append(indexes, i)
+		}
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return history[indexes[i]].Size > history[indexes[j]].Size
+	})
+
+	if len(indexes) > n {
+		indexes = indexes[:n]
+	}
+
+	largest := make(map[int]bool)
+	for _, i := range indexes {
+		largest[i] = true
+	}
+
+	return largest
+}
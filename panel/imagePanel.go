@@ -3,8 +3,8 @@ package panel
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
-	"time"
 
 	docker "github.com/fsouza/go-dockerclient"
 	"github.com/jroimartin/gocui"
@@ -21,6 +21,7 @@ type ImageList struct {
 	Items          Items
 	selectedImage  *Image
 	filter         string
+	marked         Marks
 }
 
 type Image struct {
@@ -38,6 +39,7 @@ func NewImageList(gui *Gui, name string, x, y, w, h int) *ImageList {
 		Position: Position{x, y, w, h},
 		Data:     make(map[string]interface{}),
 		Items:    Items{},
+		marked:   make(Marks),
 	}
 
 	return i
@@ -103,17 +105,6 @@ func (i *ImageList) SetView(g *gocui.Gui) error {
 
 	i.SetKeyBinding()
 
-	//  monitoring container status interval 5s
-	go func() {
-		for {
-			i.Update(func(g *gocui.Gui) error {
-				i.Refresh(g, v)
-				return nil
-			})
-			time.Sleep(5 * time.Second)
-		}
-	}()
-
 	return nil
 }
 
@@ -148,15 +139,42 @@ func (i *ImageList) SetKeyBinding() {
 	if err := i.SetKeybinding(i.name, 'c', gocui.ModNone, i.CreateContainerPanel); err != nil {
 		panic(err)
 	}
+	if err := i.SetKeybinding(i.name, 'r', gocui.ModNone, i.RunContainerPanel); err != nil {
+		panic(err)
+	}
 	if err := i.SetKeybinding(i.name, 'p', gocui.ModNone, i.PullImagePanel); err != nil {
 		panic(err)
 	}
+	if err := i.SetKeybinding(i.name, 'P', gocui.ModNone, i.PushImagePanel); err != nil {
+		panic(err)
+	}
+	if err := i.SetKeybinding(i.name, 't', gocui.ModNone, i.TagImagePanel); err != nil {
+		panic(err)
+	}
+	if err := i.SetKeybinding(i.name, 'b', gocui.ModNone, i.BuildImagePanel); err != nil {
+		panic(err)
+	}
+	if err := i.SetKeybinding(i.name, 'H', gocui.ModNone, i.HistoryPanel); err != nil {
+		panic(err)
+	}
+	if err := i.SetKeybinding(i.name, gocui.KeySpace, gocui.ModNone, i.Mark); err != nil {
+		panic(err)
+	}
+	if err := i.SetKeybinding(i.name, 'a', gocui.ModNone, i.MarkAll); err != nil {
+		panic(err)
+	}
 	if err := i.SetKeybinding(i.name, 'd', gocui.ModNone, i.RemoveImage); err != nil {
 		panic(err)
 	}
 	if err := i.SetKeybinding(i.name, gocui.KeyCtrlD, gocui.ModNone, i.RemoveDanglingImages); err != nil {
 		panic(err)
 	}
+	if err := i.SetKeybinding(i.name, gocui.KeyCtrlP, gocui.ModNone, i.PruneImagesPanel); err != nil {
+		panic(err)
+	}
+	if err := i.SetKeybinding(i.name, 'B', gocui.ModNone, i.PruneBuildCache); err != nil {
+		panic(err)
+	}
 	if err := i.SetKeybinding(i.name, 's', gocui.ModNone, i.SaveImagePanel); err != nil {
 		panic(err)
 	}
@@ -192,37 +210,78 @@ func (i *ImageList) selected() (*Image, error) {
 	return i.Images[index], nil
 }
 
+// Select moves the cursor to the image specified by id or repo:tag,
+// and reports whether it is in the list.
+func (i *ImageList) Select(id string) bool {
+	v, err := i.View(i.name)
+	if err != nil {
+		return false
+	}
+
+	id = strings.TrimPrefix(id, "sha256:")
+
+	for index, image := range i.Images {
+		name := fmt.Sprintf("%s:%s", image.Repo, image.Tag)
+		if strings.HasPrefix(id, image.ID) || id == name || id+":latest" == name {
+			SelectLine(v, index)
+			return true
+		}
+	}
+
+	return false
+}
+
 func (i *ImageList) CreateContainerPanel(g *gocui.Gui, v *gocui.View) error {
+	return i.containerPanel(CreateContainerPanel, i.CreateContainer)
+}
+
+func (i *ImageList) RunContainerPanel(g *gocui.Gui, v *gocui.View) error {
+	return i.containerPanel(RunContainerPanel, i.RunContainer)
+}
+
+func (i *ImageList) containerPanel(name string, handler func(g *gocui.Gui, v *gocui.View) error) error {
 	i.NextPanel = i.name
 
-	name, err := i.GetImageName()
+	image, err := i.GetImageName()
 	if err != nil {
 		i.ErrMessage(err.Error(), i.NextPanel)
 		return nil
 	}
 
 	i.Data = map[string]interface{}{
-		"Image": name,
+		"Image":      image,
+		"Restart":    "no",
+		"Privileged": "n",
+		"Remove":     "n",
+		"Attach":     "n",
 	}
 
 	maxX, maxY := i.Size()
 	x := maxX / 8
-	y := maxY / 8
+	y := 1
 	w := maxX - x
-	h := maxY - y
+	h := maxY - 3
 
-	i.ClosePanelName = CreateContainerPanel
-	i.Items = i.NewCreateContainerItems(x, y, w, h)
+	i.ClosePanelName = name
+	i.Items = NewCreateContainerItems(x, y, w, h)
 
 	handlers := Handlers{
-		gocui.KeyEnter: i.CreateContainer,
+		gocui.KeyEnter: handler,
 	}
 
-	NewInput(i.Gui, CreateContainerPanel, x, y, w, h, i.Items, i.Data, handlers)
+	NewInput(i.Gui, name, x, y, w, h, i.Items, i.Data, handlers)
 	return nil
 }
 
 func (i *ImageList) CreateContainer(g *gocui.Gui, v *gocui.View) error {
+	return i.createContainer(g, v, "container creating...", i.Docker.CreateContainerWithOptions)
+}
+
+func (i *ImageList) RunContainer(g *gocui.Gui, v *gocui.View) error {
+	return i.createContainer(g, v, "container starting...", i.Docker.RunContainerWithOptions)
+}
+
+func (i *ImageList) createContainer(g *gocui.Gui, v *gocui.View, message string, create func(docker.CreateContainerOptions) error) error {
 	data, err := i.GetItemsToMap(i.Items)
 	if err != nil {
 		i.ClosePanel(g, v)
@@ -240,12 +299,12 @@ func (i *ImageList) CreateContainer(g *gocui.Gui, v *gocui.View) error {
 
 	g.Update(func(g *gocui.Gui) error {
 		i.ClosePanel(g, v)
-		i.StateMessage("container creating...")
+		i.StateMessage(message)
 
 		g.Update(func(g *gocui.Gui) error {
 			defer i.CloseStateMessage()
 
-			if err := i.Docker.CreateContainerWithOptions(options); err != nil {
+			if err := create(options); err != nil {
 				i.ErrMessage(err.Error(), i.NextPanel)
 				return nil
 			}
@@ -282,35 +341,156 @@ func (i *ImageList) PullImagePanel(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (i *ImageList) PullImage(g *gocui.Gui, v *gocui.View) error {
+	line := ReadLine(v, nil)
+	if line == "" {
+		return nil
+	}
+
+	// the port of the registry is not the tag
+	name, tag := docker.ParseRepositoryTag(line)
+	if tag == "" {
+		tag = "latest"
+	}
+
+	i.ClosePanel(g, v)
 
-	item := strings.SplitN(ReadLine(v, nil), ":", 2)
+	options := docker.PullImageOptions{
+		Repository: name,
+		Tag:        tag,
+	}
 
-	if len(item) == 0 {
+	i.PullImageWithProgress(options, func(g *gocui.Gui, err error) {
+		if err != nil {
+			i.ErrMessage(err.Error(), i.NextPanel)
+			return
+		}
+
+		i.Refresh(g, v)
+	})
+
+	return nil
+}
+
+func (i *ImageList) PushImagePanel(g *gocui.Gui, v *gocui.View) error {
+	i.NextPanel = i.name
+
+	image, err := i.selected()
+	if err != nil {
+		i.ErrMessage(err.Error(), i.NextPanel)
 		return nil
 	}
 
-	name := item[0]
-	var tag string
+	i.Data = map[string]interface{}{}
+	if image.Repo != "<none>" {
+		i.Data["Repository"] = image.Repo
+	}
+	if image.Tag != "<none>" {
+		i.Data["Tag"] = image.Tag
+	}
+
+	maxX, maxY := i.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 6
 
-	if len(item) == 1 {
-		tag = "latest"
-	} else {
-		tag = item[1]
+	i.ClosePanelName = PushImagePanel
+	i.Items = i.NewPushImageItems(x, y, w, h)
+
+	handlers := Handlers{
+		gocui.KeyEnter: i.PushImage,
+	}
+
+	NewInput(i.Gui, PushImagePanel, x, y, w, h, i.Items, i.Data, handlers)
+	return nil
+}
+
+func (i *ImageList) PushImage(g *gocui.Gui, v *gocui.View) error {
+	data, err := i.GetItemsToMap(i.Items)
+	if err != nil {
+		i.ClosePanel(g, v)
+		i.ErrMessage(err.Error(), i.NextPanel)
+		return nil
+	}
+
+	if data["Repository"] == "" {
+		return nil
+	}
+
+	i.ClosePanel(g, v)
+
+	options := docker.PushImageOptions{
+		Name: data["Repository"],
+		Tag:  data["Tag"],
+	}
+
+	i.PushImageWithProgress(options, func(g *gocui.Gui, err error) {
+		if err != nil {
+			i.ErrMessage(err.Error(), i.NextPanel)
+			return
+		}
+
+		i.SwitchPanel(i.NextPanel)
+	})
+
+	return nil
+}
+
+func (i *ImageList) TagImagePanel(g *gocui.Gui, v *gocui.View) error {
+	i.NextPanel = i.name
+
+	name, err := i.GetImageName()
+	if err != nil {
+		i.ErrMessage(err.Error(), i.NextPanel)
+		return nil
+	}
+
+	maxX, maxY := i.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 8
+
+	i.ClosePanelName = TagImagePanel
+	i.Items = i.NewTagImageItems(x, y, w, h)
+
+	i.Data = map[string]interface{}{
+		"Image": name,
+	}
+
+	handlers := Handlers{
+		gocui.KeyEnter: i.TagImage,
+	}
+
+	NewInput(i.Gui, TagImagePanel, x, y, w, h, i.Items, i.Data, handlers)
+	return nil
+}
+
+func (i *ImageList) TagImage(g *gocui.Gui, v *gocui.View) error {
+	data, err := i.GetItemsToMap(i.Items)
+	if err != nil {
+		i.ClosePanel(g, v)
+		i.ErrMessage(err.Error(), i.NextPanel)
+		return nil
+	}
+
+	if data["Image"] == "" || data["Repository"] == "" {
+		return nil
+	}
+
+	options := docker.TagImageOptions{
+		Repo: data["Repository"],
+		Tag:  data["Tag"],
 	}
 
 	g.Update(func(g *gocui.Gui) error {
 		i.ClosePanel(g, v)
-		i.StateMessage("image pulling...")
+		i.StateMessage("image tagging...")
 
 		g.Update(func(g *gocui.Gui) error {
 			defer i.CloseStateMessage()
 
-			options := docker.PullImageOptions{
-				Repository: name,
-				Tag:        tag,
-			}
-
-			if err := i.Docker.PullImageWithOptions(options); err != nil {
+			if err := i.Docker.TagImageWithOptions(data["Image"], options); err != nil {
 				i.ErrMessage(err.Error(), i.NextPanel)
 				return nil
 			}
@@ -319,7 +499,6 @@ func (i *ImageList) PullImage(g *gocui.Gui, v *gocui.View) error {
 			i.SwitchPanel(i.NextPanel)
 
 			return nil
-
 		})
 
 		return nil
@@ -328,6 +507,114 @@ func (i *ImageList) PullImage(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
+func (i *ImageList) BuildImagePanel(g *gocui.Gui, v *gocui.View) error {
+	i.NextPanel = i.name
+
+	maxX, maxY := i.Size()
+	x := maxX / 8
+	y := maxY / 4
+	w := maxX - x
+	h := maxY - y
+
+	i.ClosePanelName = BuildImagePanel
+	i.Items = i.NewBuildImageItems(x, y, w, h)
+
+	i.Data = map[string]interface{}{
+		"ContextDir": ".",
+		"Dockerfile": "Dockerfile",
+		"NoCache":    "n",
+		"Pull":       "n",
+	}
+
+	handlers := Handlers{
+		gocui.KeyEnter: i.BuildImage,
+	}
+
+	NewInput(i.Gui, BuildImagePanel, x, y, w, h, i.Items, i.Data, handlers)
+	return nil
+}
+
+func (i *ImageList) BuildImage(g *gocui.Gui, v *gocui.View) error {
+	data, err := i.GetItemsToMap(i.Items)
+	if err != nil {
+		i.ClosePanel(g, v)
+		i.ErrMessage(err.Error(), i.NextPanel)
+		return nil
+	}
+
+	if data["ContextDir"] == "" {
+		return nil
+	}
+
+	contextDir, err := filepath.Abs(data["ContextDir"])
+	if err != nil {
+		i.ClosePanel(g, v)
+		i.ErrMessage(err.Error(), i.NextPanel)
+		return nil
+	}
+
+	// the Dockerfile path is relative to the context directory
+	dockerfile := data["Dockerfile"]
+	if filepath.IsAbs(dockerfile) {
+		if dockerfile, err = filepath.Rel(contextDir, dockerfile); err != nil {
+			i.ClosePanel(g, v)
+			i.ErrMessage(err.Error(), i.NextPanel)
+			return nil
+		}
+	}
+
+	if strings.HasPrefix(filepath.ToSlash(dockerfile), "../") {
+		i.ClosePanel(g, v)
+		i.ErrMessage("Dockerfile must be in the context directory", i.NextPanel)
+		return nil
+	}
+
+	var args []docker.BuildArg
+	for _, arg := range strings.Fields(data["BuildArgs"]) {
+		// the value is passed as it is, and the name only takes the value from the environment like docker build
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 {
+			value, ok := os.LookupEnv(arg)
+			if !ok {
+				i.ClosePanel(g, v)
+				i.ErrMessage(fmt.Sprintf("invalid build arg: %s", arg), i.NextPanel)
+				return nil
+			}
+			kv = []string{arg, value}
+		}
+
+		args = append(args, docker.BuildArg{Name: kv[0], Value: kv[1]})
+	}
+
+	var name string
+	tags := strings.Fields(data["Tags"])
+	if len(tags) > 0 {
+		name = tags[0]
+		tags = tags[1:]
+	}
+
+	options := docker.BuildImageOptions{
+		Name:           name,
+		ContextDir:     contextDir,
+		Dockerfile:     dockerfile,
+		BuildArgs:      args,
+		Target:         data["Target"],
+		NoCache:        data["NoCache"] == "y",
+		Pull:           data["Pull"] == "y",
+		RmTmpContainer: true,
+	}
+
+	i.ClosePanel(g, v)
+
+	maxX, maxY := g.Size()
+	build := NewBuild(i.Gui, BuildPanel, maxX/7, 1, maxX-(maxX/7), maxY-4, options, tags)
+	if err := build.SetView(g); err != nil {
+		panic(err)
+	}
+
+	return nil
+}
+
 func (i *ImageList) DetailImage(g *gocui.Gui, v *gocui.View) error {
 	i.NextPanel = i.name
 
@@ -358,7 +645,7 @@ func (i *ImageList) DetailImage(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
-func (i *ImageList) SaveImagePanel(g *gocui.Gui, v *gocui.View) error {
+func (i *ImageList) HistoryPanel(g *gocui.Gui, v *gocui.View) error {
 	i.NextPanel = i.name
 
 	name, err := i.GetImageName()
@@ -367,6 +654,24 @@ func (i *ImageList) SaveImagePanel(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
+	maxX, maxY := g.Size()
+	history := NewHistoryList(i.Gui, HistoryPanel, maxX/7, 1, maxX-(maxX/7), maxY-4, name)
+	if err := history.SetView(g); err != nil {
+		i.ErrMessage(err.Error(), i.NextPanel)
+	}
+
+	return nil
+}
+
+func (i *ImageList) SaveImagePanel(g *gocui.Gui, v *gocui.View) error {
+	i.NextPanel = i.name
+
+	names, err := i.targets()
+	if err != nil {
+		i.ErrMessage(err.Error(), i.NextPanel)
+		return nil
+	}
+
 	maxX, maxY := i.Size()
 	x := maxX / 8
 	y := maxY / 3
@@ -377,7 +682,7 @@ func (i *ImageList) SaveImagePanel(g *gocui.Gui, v *gocui.View) error {
 	i.Items = i.NewSaveImageItems(x, y, w, h)
 
 	i.Data = map[string]interface{}{
-		"ID": name,
+		"Names": names,
 	}
 
 	handlers := Handlers{
@@ -409,12 +714,19 @@ func (i *ImageList) SaveImage(g *gocui.Gui, v *gocui.View) error {
 			}
 			defer file.Close()
 
-			options := docker.ExportImageOptions{
-				Name:         i.Data["ID"].(string),
+			names := i.Data["Names"].([]string)
+			options := docker.ExportImagesOptions{
+				Names:        names,
 				OutputStream: file,
 			}
 
-			if err := i.Docker.SaveImageWithOptions(options); err != nil {
+			err = i.Docker.SaveImagesWithOptions(options)
+			for _, name := range names {
+				delete(i.marked, name)
+			}
+			i.Refresh(g, v)
+
+			if err != nil {
 				i.ErrMessage(err.Error(), i.NextPanel)
 				return nil
 			}
@@ -556,17 +868,11 @@ func (i *ImageList) GetImageList(v *gocui.View) {
 	v.Clear()
 	i.Images = make([]*Image, 0)
 
+	var names []string
 	for _, image := range i.Docker.Images(docker.ListImagesOptions{}) {
 		for _, repoTag := range image.RepoTags {
 			repo, tag := ParseRepoTag(repoTag)
 
-			if i.filter != "" {
-				name := fmt.Sprintf("%s:%s", repo, tag)
-				if strings.Index(strings.ToLower(name), strings.ToLower(i.filter)) == -1 {
-					continue
-				}
-			}
-
 			id := image.ID[7:19]
 			created := ParseDateToString(image.Created)
 			size := ParseSizeToString(image.Size)
@@ -579,11 +885,72 @@ func (i *ImageList) GetImageList(v *gocui.View) {
 				Size:    size,
 			}
 
-			i.Images = append(i.Images, image)
+			names = append(names, image.name())
+
+			if i.filter != "" {
+				name := fmt.Sprintf("%s:%s", repo, tag)
+				if strings.Index(strings.ToLower(name), strings.ToLower(i.filter)) == -1 {
+					continue
+				}
+			}
 
-			common.OutputFormatedLine(v, image)
+			i.Images = append(i.Images, image)
 		}
 	}
+
+	i.marked.Retain(names)
+	i.DisplayImageList(v)
+}
+
+func (i *ImageList) DisplayImageList(v *gocui.View) {
+	v.Clear()
+
+	for _, image := range i.Images {
+		OutputMarkedLine(v, i.marked[image.name()], image)
+	}
+}
+
+func (i *ImageList) names() []string {
+	var names []string
+	for _, image := range i.Images {
+		names = append(names, image.name())
+	}
+
+	return names
+}
+
+// targets returns the names of the marked images, or the selected image if nothing is marked.
+func (i *ImageList) targets() ([]string, error) {
+	if marked := i.marked.Filter(i.names()); len(marked) > 0 {
+		return marked, nil
+	}
+
+	name, err := i.GetImageName()
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{name}, nil
+}
+
+func (i *ImageList) Mark(g *gocui.Gui, v *gocui.View) error {
+	image, err := i.selected()
+	if err != nil {
+		return nil
+	}
+
+	i.marked.Toggle(image.name())
+	i.DisplayImageList(v)
+
+	return CursorDown(g, v)
+}
+
+// MarkAll marks all images shown with the filter, or unmarks them if all of them are marked.
+func (i *ImageList) MarkAll(g *gocui.Gui, v *gocui.View) error {
+	i.marked.ToggleAll(i.names())
+	i.DisplayImageList(v)
+
+	return nil
 }
 
 func (i *ImageList) GetImageName() (string, error) {
@@ -592,30 +959,37 @@ func (i *ImageList) GetImageName() (string, error) {
 		return "", err
 	}
 
-	var name string
+	return image.name(), nil
+}
+
+// name returns the name to specify the image.
+func (image *Image) name() string {
 	if image.Repo == "<none>" || image.Tag == "<none>" {
-		name = image.ID
-	} else {
-		name = fmt.Sprintf("%s:%s", image.Repo, image.Tag)
+		return image.ID
 	}
 
-	return name, nil
+	return fmt.Sprintf("%s:%s", image.Repo, image.Tag)
 }
 
 func (i *ImageList) RemoveImage(g *gocui.Gui, v *gocui.View) error {
 	i.NextPanel = i.name
 
-	name, err := i.GetImageName()
+	names, err := i.targets()
 	if err != nil {
 		i.ErrMessage(err.Error(), i.NextPanel)
 		return nil
 	}
 
-	i.ConfirmMessage("Are you sure you want to remove this image? (y/n)", func(g *gocui.Gui, v *gocui.View) error {
+	i.ConfirmMessage(fmt.Sprintf("Are you sure you want to remove %s? (y/n)", confirmTarget(len(names), "image")), func(g *gocui.Gui, v *gocui.View) error {
 		defer i.Refresh(g, v)
 		defer i.CloseConfirmMessage(g, v)
 
-		if err := i.Docker.RemoveImageWithName(name); err != nil {
+		err := BulkDo(names, idName, i.Docker.RemoveImageWithName)
+		for _, name := range names {
+			delete(i.marked, name)
+		}
+
+		if err != nil {
 			i.ErrMessage(err.Error(), i.NextPanel)
 			return nil
 		}
@@ -634,17 +1008,54 @@ func (i *ImageList) RemoveDanglingImages(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
-	i.ConfirmMessage("Are you sure you want to remove dangling images? (y/n)", func(g *gocui.Gui, v *gocui.View) error {
-		defer i.Refresh(g, v)
-		defer i.CloseConfirmMessage(g, v)
+	// the same as the prune without filters, so the reclaimed space is shown
+	i.PruneImagesWithFilters(g, false, "", "")
+	return nil
+}
 
-		if err := i.Docker.RemoveDanglingImages(); err != nil {
-			i.ErrMessage(err.Error(), i.NextPanel)
-			return nil
-		}
+func (i *ImageList) PruneImagesPanel(g *gocui.Gui, v *gocui.View) error {
+	i.NextPanel = i.name
+
+	i.Data = map[string]interface{}{
+		"All": "n",
+	}
+
+	maxX, maxY := i.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 8
+
+	i.ClosePanelName = PruneImagesPanel
+	i.Items = NewItems([]string{"All", "Until", "Labels"}, x, y, w, h, 8)
+
+	handlers := Handlers{
+		gocui.KeyEnter: i.PruneImages,
+	}
+
+	NewInput(i.Gui, PruneImagesPanel, x, y, w, h, i.Items, i.Data, handlers)
+	return nil
+}
 
+func (i *ImageList) PruneImages(g *gocui.Gui, v *gocui.View) error {
+	data, err := i.GetItemsToMap(i.Items)
+	if err != nil {
+		i.ClosePanel(g, v)
+		i.ErrMessage(err.Error(), i.NextPanel)
 		return nil
-	})
+	}
+
+	i.ClosePanel(g, v)
+
+	i.PruneImagesWithFilters(g, data["All"] == "y", data["Until"], data["Labels"])
+
+	return nil
+}
+
+func (i *ImageList) PruneBuildCache(g *gocui.Gui, v *gocui.View) error {
+	i.NextPanel = i.name
+
+	i.ConfirmPruneBuildCache(false)
 	return nil
 }
 
@@ -722,18 +1133,61 @@ func (i *ImageList) NewPullImageItems(ix, iy, iw, ih int) Items {
 	return NewItems(names, ix, iy, iw, ih, 6)
 }
 
-func (i *ImageList) NewCreateContainerItems(ix, iy, iw, ih int) Items {
+func (i *ImageList) NewPushImageItems(ix, iy, iw, ih int) Items {
+	names := []string{
+		"Repository",
+		"Tag",
+	}
+
+	return NewItems(names, ix, iy, iw, ih, 12)
+}
+
+func (i *ImageList) NewTagImageItems(ix, iy, iw, ih int) Items {
+	names := []string{
+		"Image",
+		"Repository",
+		"Tag",
+	}
+
+	return NewItems(names, ix, iy, iw, ih, 12)
+}
+
+func (i *ImageList) NewBuildImageItems(ix, iy, iw, ih int) Items {
+	names := []string{
+		"ContextDir",
+		"Dockerfile",
+		"Tags",
+		"BuildArgs",
+		"Target",
+		"NoCache",
+		"Pull",
+	}
+
+	return NewItems(names, ix, iy, iw, ih, 12)
+}
+
+func NewCreateContainerItems(ix, iy, iw, ih int) Items {
 	names := []string{
 		"Name",
-		"HostPort",
-		"Port",
-		"HostVolume",
-		"Volume",
 		"Image",
-		"Attach",
-		"Env",
 		"Cmd",
+		"Entrypoint",
+		"Env",
+		"Ports",
+		"Volumes",
+		"WorkingDir",
+		"User",
+		"Hostname",
+		"Labels",
+		"Network",
+		"Restart",
+		"Memory",
+		"CPUs",
+		"CapAdd",
+		"Privileged",
+		"Remove",
+		"Attach",
 	}
 
-	return NewItems(names, ix, iy, iw, ih, 12)
+	return NewColumnItems(names, 2, ix, iy, iw, ih, 12)
 }
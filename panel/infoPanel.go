@@ -20,6 +20,7 @@ type DockerInfo struct {
 	KernelVersion string
 	OSType        string
 	Architecture  string
+	Context       string
 	Endpoint      string
 	Containers    int
 	Images        int
@@ -79,6 +80,7 @@ func NewDockerInfo(gui *Gui) *DockerInfo {
 		KernelVersion: info.KernelVersion,
 		OSType:        info.OSType,
 		Architecture:  info.Architecture,
+		Context:       gui.Context,
 		Endpoint:      gui.Docker.Endpoint(),
 		Containers:    info.Containers,
 		Images:        info.Images,
@@ -89,16 +89,17 @@ func (i *Input) SetView(g *gocui.Gui) error {
 				v.Editable = true
 				v.Editor = i
 
-				if index == 0 {
-					SetCurrentPanel(g, name)
+				if strings.HasPrefix(name, "Password") {
+					v.Mask = '*'
 				}
 
-				if name == "ImageInput" {
-					fmt.Fprint(v, i.Data["Image"])
+				if index == 0 {
+					SetCurrentPanel(g, name)
 				}
 
-				if name == "ContainerInput" {
-					fmt.Fprint(v, i.Data["Container"])
+				// set default value
+				if value, ok := i.Data[strings.TrimSuffix(name, "Input")]; ok {
+					fmt.Fprint(v, value)
 				}
 
 				// set kyebinding
@@ -254,6 +255,42 @@ func NewItems(labels []string, ix, iy, iw, ih, wl int) Items {
 	return items
 }
 
+// NewColumnItems arranges the items in columns from top to bottom for the forms which have many items.
+func NewColumnItems(labels []string, cols, ix, iy, iw, ih, wl int) Items {
+
+	var items Items
+
+	rows := (len(labels) + cols - 1) / cols
+	cw := (iw - ix) / cols // column width
+	bh := 2                // input box height
+	th := ((ih - iy) - rows*bh) / (rows + 1)
+	if th < 0 {
+		th = 0
+	}
+
+	for i, name := range labels {
+		col := i / rows
+		row := i % rows
+
+		x := col*cw + 2 // label start position
+		w := x + wl     // label length
+		y := th + row*(bh+th)
+		h := y + bh
+
+		x1 := w + 1
+		w1 := (col+1)*cw - 2
+
+		item := Item{
+			Label: map[string]Position{name: {x, y, w, h}},
+			Input: map[string]Position{name + "Input": {x1, y, w1, h}},
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
 func ParseDateToString(unixtime int64) string {
 	t := time.Unix(unixtime, 0)
 	return t.Format("2006/01/02 15:04:05")
@@ -264,6 +301,23 @@ func ParseSizeToString(size int64) string {
 	return fmt.Sprintf("%.1fMB", mb)
 }
 
+func ParseBytesToString(size uint64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+
+	value := float64(size)
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+
+	if unit == 0 {
+		return fmt.Sprintf("%d%s", size, units[unit])
+	}
+
+	return fmt.Sprintf("%.2f%s", value, units[unit])
+}
+
 func ParsePortToString(ports []docker.APIPort) string {
 	var port string
 	for _, p := range ports {
@@ -276,9 +330,10 @@ func ParsePortToString(ports []docker.APIPort) string {
 	return port
 }
 
+// ParseRepoTag splits the repository and the tag at the last colon after the last slash,
+// so the port of the registry is kept in the repository.
 func ParseRepoTag(repoTag string) (string, string) {
-	tmp := strings.SplitN(repoTag, ":", 2)
-	return tmp[0], tmp[1]
+	return docker.ParseRepositoryTag(repoTag)
 }
 
 func ParseLabels(labels map[string]string) string {
@@ -0,0 +1,238 @@
+package panel
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/jroimartin/gocui"
+)
+
+type Logs struct {
+	*Gui
+	Position
+	name    string
+	options docker.LogsOptions
+	cancel  context.CancelFunc
+}
+
+// viewWriter writes a stream to the view in the main loop.
+// It drops the data after ctx is done so a stopped stream never writes to a reopened view.
+type viewWriter struct {
+	*Gui
+	ctx   context.Context
+	name  string
+	color string
+	mu    sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (w *viewWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	// the updates of gocui are not ordered, so each update flushes the buffered data
+	w.mu.Lock()
+	if w.color != "" {
+		w.buf.WriteString(w.color)
+	}
+	w.buf.Write(p)
+	if w.color != "" {
+		w.buf.WriteString("\x1b[0m")
+	}
+	w.mu.Unlock()
+
+	w.Update(func(g *gocui.Gui) error {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		if w.ctx.Err() != nil || w.buf.Len() == 0 {
+			return nil
+		}
+
+		v, err := g.View(w.name)
+		if err != nil {
+			return nil
+		}
+
+		v.Write(w.buf.Bytes())
+		w.buf.Reset()
+		return nil
+	})
+
+	return len(p), nil
+}
+
+func NewLogs(gui *Gui, name string, x, y, w, h int, options docker.LogsOptions) *Logs {
+	return &Logs{
+		Gui:      gui,
+		name:     name,
+		Position: Position{x, y, w, h},
+		options:  options,
+	}
+}
+
+func (l *Logs) Name() string {
+	return l.name
+}
+
+func (l *Logs) SetView(g *gocui.Gui) error {
+	v, err := g.SetView(l.name, l.x, l.y, l.w, l.h)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Wrap = true
+		v.SelBgColor = gocui.ColorCyan
+	}
+
+	l.SetKeyBinding()
+	l.SwitchPanel(l.name)
+	l.Stream(v)
+
+	return nil
+}
+
+func (l *Logs) SetKeyBinding() {
+	if err := l.SetKeybinding(l.name, 'j', gocui.ModNone, CursorDown); err != nil {
+		panic(err)
+	}
+	if err := l.SetKeybinding(l.name, 'k', gocui.ModNone, l.scroll(CursorUp)); err != nil {
+		panic(err)
+	}
+	if err := l.SetKeybinding(l.name, 'd', gocui.ModNone, PageDown); err != nil {
+		panic(err)
+	}
+	if err := l.SetKeybinding(l.name, 'u', gocui.ModNone, l.scroll(PageUp)); err != nil {
+		panic(err)
+	}
+	if err := l.SetKeybinding(l.name, 'f', gocui.ModNone, l.ToggleFollow); err != nil {
+		panic(err)
+	}
+	if err := l.SetKeybinding(l.name, 't', gocui.ModNone, l.ToggleTimestamps); err != nil {
+		panic(err)
+	}
+	if err := l.SetKeybinding(l.name, gocui.KeyEsc, gocui.ModNone, l.ClosePanel); err != nil {
+		panic(err)
+	}
+	if err := l.SetKeybinding(l.name, 'q', gocui.ModNone, l.ClosePanel); err != nil {
+		panic(err)
+	}
+	if err := l.SetKeybinding(l.name, gocui.KeyCtrlQ, gocui.ModNone, l.quit); err != nil {
+		panic(err)
+	}
+}
+
+// scroll stops the autoscroll so that the user can read back the logs while following.
+func (l *Logs) scroll(f func(g *gocui.Gui, v *gocui.View) error) func(g *gocui.Gui, v *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		v.Autoscroll = false
+		return f(g, v)
+	}
+}
+
+func (l *Logs) Refresh(g *gocui.Gui, v *gocui.View) error {
+	return nil
+}
+
+// Stream starts streaming the logs to v, stopping the previous stream.
+func (l *Logs) Stream(v *gocui.View) {
+	l.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l.cancel = cancel
+
+	v.Clear()
+	v.SetOrigin(0, 0)
+	v.SetCursor(0, 0)
+	v.Autoscroll = l.options.Follow
+	v.Title = l.title()
+
+	stderr := &viewWriter{Gui: l.Gui, ctx: ctx, name: l.name, color: "\x1b[31m"}
+
+	options := l.options
+	options.Context = ctx
+	options.OutputStream = &viewWriter{Gui: l.Gui, ctx: ctx, name: l.name}
+	options.ErrorStream = stderr
+
+	go func() {
+		if err := l.Docker.Logs(options); err != nil && ctx.Err() == nil {
+			fmt.Fprintln(stderr, err)
+		}
+	}()
+}
+
+// Stop stops streaming the logs.
+func (l *Logs) Stop() {
+	if l.cancel != nil {
+		l.cancel()
+		l.cancel = nil
+	}
+}
+
+func (l *Logs) title() string {
+	title := fmt.Sprintf("%s %s (tail:%s", l.name, l.options.Container, l.options.Tail)
+	if l.options.Follow {
+		title += " follow"
+	}
+	if l.options.Timestamps {
+		title += " timestamps"
+	}
+
+	return title + ")"
+}
+
+func (l *Logs) ToggleFollow(g *gocui.Gui, v *gocui.View) error {
+	l.options.Follow = !l.options.Follow
+	l.Stream(v)
+	return nil
+}
+
+func (l *Logs) ToggleTimestamps(g *gocui.Gui, v *gocui.View) error {
+	l.options.Timestamps = !l.options.Timestamps
+	l.Stream(v)
+	return nil
+}
+
+func (l *Logs) ClosePanel(g *gocui.Gui, v *gocui.View) error {
+	l.Stop()
+
+	if err := l.DeleteView(l.name); err != nil {
+		panic(err)
+	}
+	l.DeleteKeybindings(l.name)
+
+	l.SwitchPanel(l.NextPanel)
+
+	return nil
+}
+
+// ParseSince parses a duration like "10m", a date like "2006-01-02",
+// RFC3339 or unix time to unix time.
+func ParseSince(since string) (int64, error) {
+	if since == "" {
+		return 0, nil
+	}
+
+	if d, err := time.ParseDuration(since); err == nil {
+		return time.Now().Add(-d).Unix(), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, since, time.Local); err == nil {
+			return t.Unix(), nil
+		}
+	}
+
+	if unix, err := strconv.ParseInt(since, 10, 64); err == nil {
+		return unix, nil
+	}
+
+	return 0, fmt.Errorf("invalid since: %s", since)
+}
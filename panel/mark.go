@@ -0,0 +1,113 @@
+package panel
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+	"github.com/skanehira/docui/common"
+)
+
+// markColor is the color of the marked rows.
+const markColor = "\x1b[33m"
+
+// Marks is the set of the marked rows of a list panel.
+type Marks map[string]bool
+
+// Toggle marks or unmarks the row.
+func (m Marks) Toggle(id string) {
+	if m[id] {
+		delete(m, id)
+	} else {
+		m[id] = true
+	}
+}
+
+// ToggleAll marks all rows, or unmarks them if all of them are marked.
+func (m Marks) ToggleAll(ids []string) {
+	all := len(ids) > 0
+	for _, id := range ids {
+		if !m[id] {
+			all = false
+			break
+		}
+	}
+
+	for _, id := range ids {
+		if all {
+			delete(m, id)
+		} else {
+			m[id] = true
+		}
+	}
+}
+
+// Retain unmarks the rows which do not exist anymore.
+// ids must be all rows of the list including the rows hidden by the filter.
+func (m Marks) Retain(ids []string) {
+	shown := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		shown[id] = true
+	}
+
+	for id := range m {
+		if !shown[id] {
+			delete(m, id)
+		}
+	}
+}
+
+// Filter returns the marked ids keeping the order of the list.
+func (m Marks) Filter(ids []string) []string {
+	var marked []string
+	for _, id := range ids {
+		if m[id] {
+			marked = append(marked, id)
+		}
+	}
+
+	return marked
+}
+
+// OutputMarkedLine outputs the row with the mark color if it is marked.
+func OutputMarkedLine(v *gocui.View, marked bool, i interface{}) {
+	if marked {
+		fmt.Fprint(v, markColor)
+		defer fmt.Fprint(v, "\x1b[0m")
+	}
+
+	common.OutputFormatedLine(v, i)
+}
+
+// BulkDo runs f for each id and returns the errors as one error
+// which shows the failed rows by name.
+func BulkDo(ids []string, name func(id string) string, f func(id string) error) error {
+	var errs []string
+
+	for _, id := range ids {
+		if err := f(id); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", name(id), err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+// idName is the name of the row for the lists whose rows are identified by the name.
+func idName(id string) string {
+	return id
+}
+
+// confirmTarget returns the word used in the confirmation for the target rows.
+func confirmTarget(count int, kind string) string {
+	if count == 1 {
+		return "this " + kind
+	}
+
+	return fmt.Sprintf("%d %ss", count, kind)
+}
@@ -68,11 +68,19 @@ func (n Navigate) SetNavigate(name string) *gocui.View {
 
 func newNavi() map[string]string {
 	return map[string]string{
-		ImageListPanel:         "j/k: select image, p: pull image, i: import image, s: save image\nCtrl+l: load image, ctrl+s: search image, d: remove image, Ctrl+d: remove dagling images, c: create container, Enter/o: inspect image, Ctrl+r: refresh images iist",
+		ImageListPanel:         "j/k: select image, space: mark image, a: mark all, p: pull image, P: push image, t: tag image, b: build image, H: show history, i: import image, s: save image\nCtrl+l: load image, ctrl+s: search image, d: remove image, Ctrl+d: remove dagling images, Ctrl+p: prune images, B: prune build cache, c: create container, r: run container, Enter/o: inspect image, Ctrl+r: refresh images iist",
 		PullImagePanel:         "Esc/Ctrl+w: close panel, Enter: pull image",
-		ContainerListPanel:     "j/k: select container, e: export container, c: commit container\nu: start container, s: stop container, d: remove container, Enter/o: inspect container, Ctrl+r: refresh container list",
+		PushImagePanel:         "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: push image",
+		BuildImagePanel:        "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: build image",
+		BuildPanel:             "j/k: cursor down/up, d/u: page down/up, Ctrl+c: cancel build, Esc/q: close panel",
+		HistoryPanel:           "j/k: select layer, Esc/q: close panel",
+		TagImagePanel:          "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: tag image",
+		ContainerListPanel:     "j/k: select container, space: mark container, a: mark all, e: export container, c: commit container\nu: start container, s: stop container, R: restart container, p/P: pause/unpause container, K: kill container, d: remove container, L: show logs, x: exec shell, S: show stats, C: clone/recreate container, b: browse files, D: show diff, t: show processes, n/N: connect/disconnect network, Ctrl+p: prune containers, Enter/o: inspect container, Ctrl+r: refresh container list",
 		DetailPanel:            "j/k: cursor down/up, d/u: page down/up",
 		CreateContainerPanel:   "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: create container",
+		RunContainerPanel:      "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: run container",
+		KillContainerPanel:     "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: kill container",
+		CloneContainerPanel:    "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: run container (recreate if the name is not changed)",
 		SaveImagePanel:         "Esc/Ctrl+w: close panel, Enter: save image",
 		ImportImagePanel:       "Esc/Ctrl+w: close panel, Enter: import image",
 		LoadImagePanel:         "Esc/Ctrl+w: close panel, Enter: load image",
@@ -82,9 +90,39 @@ func newNavi() map[string]string {
 		SearchImageResultPanel: "j/k: select image, Esc/Ctrl+w: close panel, Enter: pull image",
 		ErrMessagePanel:        "Enter: close",
 		ConfirmMessagePanel:    "y/Enter: confirm, n: cancel",
-		VolumeListPanel:        "j/k: select volume, c: create volume, d: remove volume, p: prune volumes, Enter/o: inspect volume, Ctrl+r: refresh volume list",
+		VolumeListPanel:        "j/k: select volume, space: mark volume, a: mark all, c: create volume, d: remove volume, p: prune volumes, Enter/o: inspect volume, Ctrl+r: refresh volume list",
 		CreateVolumePanel:      "Esc/Ctrl+w: close panel, Enter: create volume",
-		NetworkListPanel:       "j/k: cursor down/up, d: remove network, o/Enter: inspect network",
+		ServiceListPanel:       "j/k: select service, t: show tasks, s: scale service, u: update image, R: rollback service, d: remove service, o/Enter: inspect service, Ctrl+r: refresh service list",
+		NodeListPanel:          "j/k: select node, t: show tasks, P/D: promote/demote node, d: drain node, p: pause node, a: activate node, o/Enter: inspect node, Ctrl+r: refresh node list",
+		SecretListPanel:        "j/k: select secret, c: create secret, d: remove secret, o/Enter: inspect secret, Ctrl+r: refresh secret list",
+		ConfigListPanel:        "j/k: select config, c: create config, d: remove config, o/Enter: inspect config, Ctrl+r: refresh config list",
+		CreateSecretPanel:      "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: create secret",
+		CreateConfigPanel:      "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: create config",
+		ScaleServicePanel:      "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: scale service",
+		UpdateServicePanel:     "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: update service",
+		TaskListPanel:          "j/k: select task, Ctrl+r: refresh, Esc/q: close panel",
+		NetworkListPanel:       "j/k: cursor down/up, space: mark network, a: mark all, c: create network, n/N: connect/disconnect container, d: remove network, p: prune networks, o/Enter: inspect network",
+		ContainerLogsPanel:     "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: show logs",
+		LogsPanel:              "j/k: cursor down/up, d/u: page down/up, f: toggle follow, t: toggle timestamps, Esc/q: close panel",
+		ExecContainerPanel:     "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: exec command",
+		StatsPanel:             "j/k: select container, Ctrl+r: reload running containers, Esc/q: close panel",
+		EventListPanel:         "j/k: cursor down/up, d/u: page down/up, t: filter type, a: filter action, Enter: jump to container/image, Esc/q: close panel",
+		ProgressPanel:          "Esc/Ctrl+c: cancel",
+		ContextListPanel:       "j/k: select context, Enter: switch context, Esc/q: close panel",
+		RegistryListPanel:      "j/k: select registry, Enter: login, d: logout, Esc/q: close panel",
+		FileListPanel:          "j/k: select file, Enter/l: open, h/Backspace: parent directory, d: copy to host, u: copy from host, Ctrl+r: refresh, Esc/q: close panel",
+		CopyFromContainerPanel: "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: copy to host",
+		CopyToContainerPanel:   "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: copy to container",
+		DiffPanel:              "j/k: cursor down/up, d/u: page down/up, c: commit container, Ctrl+r: refresh, Esc/q: close panel",
+		TopPanel:               "j/k: select process, K: send signal, Ctrl+r: refresh, Esc/q: close panel",
+		CreateNetworkPanel:     "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: create network",
+		ConnectNetworkPanel:    "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: connect network",
+		DisconnectNetworkPanel: "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: disconnect network",
+		PruneImagesPanel:       "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: prune images",
+		ResultMessagePanel:     "j/k: cursor down/up, Enter: close",
+		DiskUsagePanel:         "j/k: select type, Enter: show largest items, p: prune, Ctrl+r: refresh, Esc/q: close panel",
+		DiskUsageItemPanel:     "j/k: cursor down/up, d/u: page down/up, p: prune, Esc/q: back to disk usage",
+		LoginPanel:             "Ctrl+j/k: change input, Esc/Ctrl+w: close panel, Enter: login",
 	}
 
 }
@@ -3,7 +3,6 @@ package panel
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/jroimartin/gocui"
 	"github.com/skanehira/docui/common"
@@ -18,6 +17,7 @@ type NetworkList struct {
 	ClosePanelName string
 	Items          Items
 	filter         string
+	marked         Marks
 }
 
 type Network struct {
@@ -35,6 +35,7 @@ func NewNetworkList(gui *Gui, name string, x, y, w, h int) *NetworkList {
 		Position: Position{x, y, w, h},
 		Data:     make(map[string]interface{}),
 		Items:    Items{},
+		marked:   make(Marks),
 	}
 
 	return n
@@ -94,21 +95,12 @@ func (n *NetworkList) SetView(g *gocui.Gui) error {
 		v.SelFgColor = gocui.ColorBlack | gocui.AttrBold
 		v.SetOrigin(0, 0)
 		v.SetCursor(0, 0)
+
+		n.GetNetworkList(v)
 	}
 
 	n.SetKeyBinding()
 
-	//  monitoring container status interval 5s
-	go func() {
-		for {
-			n.Update(func(g *gocui.Gui) error {
-				n.Refresh(g, v)
-				return nil
-			})
-			time.Sleep(5 * time.Second)
-		}
-	}()
-
 	return nil
 }
 
@@ -146,9 +138,27 @@ func (n *NetworkList) SetKeyBinding() {
 	if err := n.SetKeybinding(n.name, gocui.KeyEnter, gocui.ModNone, n.Detail); err != nil {
 		panic(err)
 	}
+	if err := n.SetKeybinding(n.name, gocui.KeySpace, gocui.ModNone, n.Mark); err != nil {
+		panic(err)
+	}
+	if err := n.SetKeybinding(n.name, 'a', gocui.ModNone, n.MarkAll); err != nil {
+		panic(err)
+	}
 	if err := n.SetKeybinding(n.name, 'd', gocui.ModNone, n.RemoveNetwork); err != nil {
 		panic(err)
 	}
+	if err := n.SetKeybinding(n.name, 'p', gocui.ModNone, n.PruneNetworks); err != nil {
+		panic(err)
+	}
+	if err := n.SetKeybinding(n.name, 'c', gocui.ModNone, n.CreateNetworkPanel); err != nil {
+		panic(err)
+	}
+	if err := n.SetKeybinding(n.name, 'n', gocui.ModNone, n.ConnectNetworkPanel); err != nil {
+		panic(err)
+	}
+	if err := n.SetKeybinding(n.name, 'N', gocui.ModNone, n.DisconnectNetworkPanel); err != nil {
+		panic(err)
+	}
 }
 
 func (n *NetworkList) selected() (*Network, error) {
@@ -210,33 +220,31 @@ func (n *NetworkList) GetNetworkList(v *gocui.View) {
 	v.Clear()
 	n.Networks = make([]*Network, 0)
 
+	containers, err := n.Docker.NetworkContainers()
+	if err != nil {
+		n.ErrMessage(err.Error(), n.NextPanel)
+		return
+	}
+
 	var keys []string
 	tmpMap := make(map[string]*Network)
 
+	var names []string
 	for _, network := range n.Docker.Networks() {
+		names = append(names, network.Name)
+
 		if n.filter != "" {
 			if strings.Index(strings.ToLower(network.Name), strings.ToLower(n.filter)) == -1 {
 				continue
 			}
 		}
 
-		var containers string
-		net, err := n.Docker.NetworkInfo(network.ID)
-		if err != nil {
-			n.ErrMessage(err.Error(), n.NextPanel)
-			return
-		}
-
-		for _, endpoint := range net.Containers {
-			containers += fmt.Sprintf("%s ", endpoint.Name)
-		}
-
 		tmpMap[network.ID[:12]] = &Network{
 			ID:         network.ID,
 			Name:       network.Name,
 			Driver:     network.Driver,
 			Scope:      network.Scope,
-			Containers: containers,
+			Containers: strings.Join(containers[network.ID], " "),
 		}
 
 		keys = append(keys, network.ID[:12])
@@ -244,10 +252,62 @@ func (n *NetworkList) GetNetworkList(v *gocui.View) {
 	}
 
 	for _, key := range common.SortKeys(keys) {
-		net := tmpMap[key]
-		common.OutputFormatedLine(v, net)
-		n.Networks = append(n.Networks, net)
+		n.Networks = append(n.Networks, tmpMap[key])
 	}
+
+	n.marked.Retain(names)
+	n.DisplayNetworkList(v)
+}
+
+func (n *NetworkList) DisplayNetworkList(v *gocui.View) {
+	v.Clear()
+
+	for _, net := range n.Networks {
+		OutputMarkedLine(v, n.marked[net.Name], net)
+	}
+}
+
+func (n *NetworkList) names() []string {
+	var names []string
+	for _, net := range n.Networks {
+		names = append(names, net.Name)
+	}
+
+	return names
+}
+
+// targets returns the names of the marked networks, or the selected network if nothing is marked.
+func (n *NetworkList) targets() ([]string, error) {
+	if marked := n.marked.Filter(n.names()); len(marked) > 0 {
+		return marked, nil
+	}
+
+	net, err := n.selected()
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{net.Name}, nil
+}
+
+func (n *NetworkList) Mark(g *gocui.Gui, v *gocui.View) error {
+	net, err := n.selected()
+	if err != nil {
+		return nil
+	}
+
+	n.marked.Toggle(net.Name)
+	n.DisplayNetworkList(v)
+
+	return CursorDown(g, v)
+}
+
+// MarkAll marks all networks shown with the filter, or unmarks them if all of them are marked.
+func (n *NetworkList) MarkAll(g *gocui.Gui, v *gocui.View) error {
+	n.marked.ToggleAll(n.names())
+	n.DisplayNetworkList(v)
+
+	return nil
 }
 
 func (n *NetworkList) Detail(g *gocui.Gui, v *gocui.View) error {
@@ -283,17 +343,22 @@ func (n *NetworkList) Detail(g *gocui.Gui, v *gocui.View) error {
 func (n *NetworkList) RemoveNetwork(g *gocui.Gui, v *gocui.View) error {
 	n.NextPanel = n.name
 
-	selected, err := n.selected()
+	names, err := n.targets()
 	if err != nil {
 		n.ErrMessage(err.Error(), n.NextPanel)
 		return nil
 	}
 
-	n.ConfirmMessage("Are you sure you want to remove this network? (y/n)", func(g *gocui.Gui, v *gocui.View) error {
+	n.ConfirmMessage(fmt.Sprintf("Are you sure you want to remove %s? (y/n)", confirmTarget(len(names), "network")), func(g *gocui.Gui, v *gocui.View) error {
 		defer n.Refresh(g, v)
 		defer n.CloseConfirmMessage(g, v)
 
-		if err := n.Docker.RemoveNetwork(selected.ID); err != nil {
+		err := BulkDo(names, idName, n.Docker.RemoveNetwork)
+		for _, name := range names {
+			delete(n.marked, name)
+		}
+
+		if err != nil {
 			n.ErrMessage(err.Error(), n.NextPanel)
 			return nil
 		}
@@ -302,3 +367,268 @@ func (n *NetworkList) RemoveNetwork(g *gocui.Gui, v *gocui.View) error {
 	})
 	return nil
 }
+
+func (n *NetworkList) PruneNetworks(g *gocui.Gui, v *gocui.View) error {
+	n.NextPanel = n.name
+
+	n.ConfirmPrune("unused networks", "networks", n.Docker.PruneUnusedNetworks)
+
+	return nil
+}
+
+func (n *NetworkList) CreateNetworkPanel(g *gocui.Gui, v *gocui.View) error {
+	n.NextPanel = n.name
+
+	n.Data = map[string]interface{}{
+		"Driver":     "bridge",
+		"Internal":   "n",
+		"Attachable": "n",
+	}
+
+	maxX, maxY := n.Size()
+	x := maxX / 8
+	y := maxY / 8
+	w := maxX - x
+	h := maxY - y
+
+	n.ClosePanelName = CreateNetworkPanel
+	n.Items = NewCreateNetworkItems(x, y, w, h)
+
+	handlers := Handlers{
+		gocui.KeyEnter: n.CreateNetwork,
+	}
+
+	NewInput(n.Gui, CreateNetworkPanel, x, y, w, h, n.Items, n.Data, handlers)
+	return nil
+}
+
+func NewCreateNetworkItems(ix, iy, iw, ih int) Items {
+	names := []string{
+		"Name",
+		"Driver",
+		"Subnet",
+		"Gateway",
+		"IPRange",
+		"Internal",
+		"Attachable",
+		"Labels",
+		"Options",
+	}
+
+	return NewItems(names, ix, iy, iw, ih, 12)
+}
+
+func (n *NetworkList) CreateNetwork(g *gocui.Gui, v *gocui.View) error {
+	data, err := n.GetItemsToMap(n.Items)
+	if err != nil {
+		n.ClosePanel(g, v)
+		n.ErrMessage(err.Error(), n.NextPanel)
+		return nil
+	}
+
+	if data["Name"] == "" {
+		return nil
+	}
+
+	options, err := n.Docker.NewCreateNetworkOptions(data)
+	if err != nil {
+		n.ClosePanel(g, v)
+		n.ErrMessage(err.Error(), n.NextPanel)
+		return nil
+	}
+
+	g.Update(func(g *gocui.Gui) error {
+		n.ClosePanel(g, v)
+		n.StateMessage("network creating...")
+
+		g.Update(func(g *gocui.Gui) error {
+			defer n.Refresh(g, v)
+			defer n.CloseStateMessage()
+
+			if err := n.Docker.CreateNetworkWithOptions(options); err != nil {
+				n.ErrMessage(err.Error(), n.NextPanel)
+				return nil
+			}
+
+			n.SwitchPanel(n.NextPanel)
+
+			return nil
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
+func (n *NetworkList) ConnectNetworkPanel(g *gocui.Gui, v *gocui.View) error {
+	n.NextPanel = n.name
+
+	net, err := n.selected()
+	if err != nil {
+		n.ErrMessage(err.Error(), n.NextPanel)
+		return nil
+	}
+
+	n.Data = map[string]interface{}{
+		"Network": net.Name,
+	}
+
+	maxX, maxY := n.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 10
+
+	n.ClosePanelName = ConnectNetworkPanel
+	n.Items = NewConnectNetworkItems(x, y, w, h)
+
+	handlers := Handlers{
+		gocui.KeyEnter: n.ConnectNetwork,
+	}
+
+	NewInput(n.Gui, ConnectNetworkPanel, x, y, w, h, n.Items, n.Data, handlers)
+	return nil
+}
+
+func (n *NetworkList) ConnectNetwork(g *gocui.Gui, v *gocui.View) error {
+	return n.connectNetwork(g, v, n.Items, n.ClosePanel)
+}
+
+func (n *NetworkList) DisconnectNetworkPanel(g *gocui.Gui, v *gocui.View) error {
+	n.NextPanel = n.name
+
+	net, err := n.selected()
+	if err != nil {
+		n.ErrMessage(err.Error(), n.NextPanel)
+		return nil
+	}
+
+	n.Data = map[string]interface{}{
+		"Network": net.Name,
+		"Force":   "n",
+	}
+
+	// fill the container if only one container is connected
+	if containers := strings.Fields(net.Containers); len(containers) == 1 {
+		n.Data["Container"] = containers[0]
+	}
+
+	maxX, maxY := n.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 8
+
+	n.ClosePanelName = DisconnectNetworkPanel
+	n.Items = NewDisconnectNetworkItems(x, y, w, h)
+
+	handlers := Handlers{
+		gocui.KeyEnter: n.DisconnectNetwork,
+	}
+
+	NewInput(n.Gui, DisconnectNetworkPanel, x, y, w, h, n.Items, n.Data, handlers)
+	return nil
+}
+
+func (n *NetworkList) DisconnectNetwork(g *gocui.Gui, v *gocui.View) error {
+	return n.disconnectNetwork(g, v, n.Items, n.ClosePanel)
+}
+
+func NewConnectNetworkItems(ix, iy, iw, ih int) Items {
+	names := []string{
+		"Network",
+		"Container",
+		"Aliases",
+		"IP",
+	}
+
+	return NewItems(names, ix, iy, iw, ih, 12)
+}
+
+func NewDisconnectNetworkItems(ix, iy, iw, ih int) Items {
+	names := []string{
+		"Network",
+		"Container",
+		"Force",
+	}
+
+	return NewItems(names, ix, iy, iw, ih, 12)
+}
+
+// connectNetwork connects the container to the network with the values of the connect network panel.
+// It is shared with the network list and the container list.
+func (gui *Gui) connectNetwork(g *gocui.Gui, v *gocui.View, items Items, closePanel func(g *gocui.Gui, v *gocui.View) error) error {
+	data, err := gui.GetItemsToMap(items)
+	if err != nil {
+		closePanel(g, v)
+		gui.ErrMessage(err.Error(), gui.NextPanel)
+		return nil
+	}
+
+	if data["Network"] == "" || data["Container"] == "" {
+		return nil
+	}
+
+	g.Update(func(g *gocui.Gui) error {
+		closePanel(g, v)
+		gui.StateMessage("network connecting...")
+
+		g.Update(func(g *gocui.Gui) error {
+			defer gui.Panels[NetworkListPanel].Refresh(g, v)
+			defer gui.CloseStateMessage()
+
+			aliases := strings.Fields(data["Aliases"])
+			if err := gui.Docker.ConnectNetworkWithContainer(data["Network"], data["Container"], aliases, data["IP"]); err != nil {
+				gui.ErrMessage(err.Error(), gui.NextPanel)
+				return nil
+			}
+
+			gui.SwitchPanel(gui.NextPanel)
+
+			return nil
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
+// disconnectNetwork disconnects the container from the network with the values of the disconnect network panel.
+// It is shared with the network list and the container list.
+func (gui *Gui) disconnectNetwork(g *gocui.Gui, v *gocui.View, items Items, closePanel func(g *gocui.Gui, v *gocui.View) error) error {
+	data, err := gui.GetItemsToMap(items)
+	if err != nil {
+		closePanel(g, v)
+		gui.ErrMessage(err.Error(), gui.NextPanel)
+		return nil
+	}
+
+	if data["Network"] == "" || data["Container"] == "" {
+		return nil
+	}
+
+	g.Update(func(g *gocui.Gui) error {
+		closePanel(g, v)
+		gui.StateMessage("network disconnecting...")
+
+		g.Update(func(g *gocui.Gui) error {
+			defer gui.Panels[NetworkListPanel].Refresh(g, v)
+			defer gui.CloseStateMessage()
+
+			if err := gui.Docker.DisconnectNetworkWithContainer(data["Network"], data["Container"], data["Force"] == "y"); err != nil {
+				gui.ErrMessage(err.Error(), gui.NextPanel)
+				return nil
+			}
+
+			gui.SwitchPanel(gui.NextPanel)
+
+			return nil
+		})
+
+		return nil
+	})
+
+	return nil
+}
@@ -0,0 +1,383 @@
+package panel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+	"github.com/skanehira/docui/common"
+)
+
+type NodeList struct {
+	*Gui
+	name string
+	Position
+	Nodes  []*Node
+	filter string
+}
+
+type Node struct {
+	ID           string `tag:"ID" len:"min:0.1 max:0.1"`
+	Hostname     string `tag:"HOSTNAME" len:"min:0.1 max:0.2"`
+	Role         string `tag:"ROLE" len:"min:0.1 max:0.1"`
+	Status       string `tag:"STATUS" len:"min:0.1 max:0.1"`
+	Availability string `tag:"AVAILABILITY" len:"min:0.1 max:0.1"`
+	Manager      string `tag:"MANAGER STATUS" len:"min:0.1 max:0.1"`
+	Engine       string `tag:"ENGINE VERSION" len:"min:0.1 max:0.1"`
+}
+
+func NewNodeList(gui *Gui, name string, x, y, w, h int) *NodeList {
+	return &NodeList{
+		Gui:      gui,
+		name:     name,
+		Position: Position{x, y, w, h},
+	}
+}
+
+func (n *NodeList) Name() string {
+	return n.name
+}
+
+func (n *NodeList) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+	switch {
+	case ch != 0 && mod == 0:
+		v.EditWrite(ch)
+	case key == gocui.KeySpace:
+		v.EditWrite(' ')
+	case key == gocui.KeyBackspace || key == gocui.KeyBackspace2:
+		v.EditDelete(true)
+	case key == gocui.KeyArrowLeft:
+		v.MoveCursor(-1, 0, false)
+		return
+	case key == gocui.KeyArrowRight:
+		v.MoveCursor(+1, 0, false)
+		return
+	}
+
+	n.filter = ReadLine(v, nil)
+
+	if v, err := n.View(n.name); err == nil {
+		n.GetNodeList(v)
+	}
+}
+
+func (n *NodeList) SetView(g *gocui.Gui) error {
+	// set header panel
+	if v, err := g.SetView(NodeListHeaderPanel, n.x, n.y, n.w, n.h); err != nil {
+		if err != gocui.ErrUnknownView {
+			panic(err)
+		}
+
+		v.Wrap = true
+		v.Frame = true
+		v.Title = v.Name()
+		v.FgColor = gocui.AttrBold | gocui.ColorWhite
+		common.OutputFormatedHeader(v, &Node{})
+	}
+
+	// set scroll panel
+	v, err := g.SetView(n.name, n.x, n.y+1, n.w, n.h)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Wrap = true
+		v.FgColor = gocui.ColorCyan
+		v.SelBgColor = gocui.ColorWhite
+		v.SelFgColor = gocui.ColorBlack | gocui.AttrBold
+		v.SetOrigin(0, 0)
+		v.SetCursor(0, 0)
+
+		n.GetNodeList(v)
+	}
+
+	n.SetKeyBinding()
+
+	return nil
+}
+
+func (n *NodeList) Refresh(g *gocui.Gui, v *gocui.View) error {
+	n.Update(func(g *gocui.Gui) error {
+		v, err := n.View(n.name)
+		if err != nil {
+			panic(err)
+		}
+		n.GetNodeList(v)
+		return nil
+	})
+
+	return nil
+}
+
+func (n *NodeList) SetKeyBinding() {
+	n.SetKeyBindingToPanel(n.name)
+
+	if err := n.SetKeybinding(n.name, 'j', gocui.ModNone, CursorDown); err != nil {
+		panic(err)
+	}
+	if err := n.SetKeybinding(n.name, 'k', gocui.ModNone, CursorUp); err != nil {
+		panic(err)
+	}
+	if err := n.SetKeybinding(n.name, gocui.KeyCtrlR, gocui.ModNone, n.Refresh); err != nil {
+		panic(err)
+	}
+	if err := n.SetKeybinding(n.name, 'f', gocui.ModNone, n.Filter); err != nil {
+		panic(err)
+	}
+	if err := n.SetKeybinding(n.name, 'o', gocui.ModNone, n.Detail); err != nil {
+		panic(err)
+	}
+	if err := n.SetKeybinding(n.name, gocui.KeyEnter, gocui.ModNone, n.Detail); err != nil {
+		panic(err)
+	}
+	if err := n.SetKeybinding(n.name, 't', gocui.ModNone, n.TaskListPanel); err != nil {
+		panic(err)
+	}
+	if err := n.SetKeybinding(n.name, 'P', gocui.ModNone, n.UpdateRole("manager", "promote")); err != nil {
+		panic(err)
+	}
+	if err := n.SetKeybinding(n.name, 'D', gocui.ModNone, n.UpdateRole("worker", "demote")); err != nil {
+		panic(err)
+	}
+	if err := n.SetKeybinding(n.name, 'd', gocui.ModNone, n.UpdateAvailability("drain")); err != nil {
+		panic(err)
+	}
+	if err := n.SetKeybinding(n.name, 'p', gocui.ModNone, n.UpdateAvailability("pause")); err != nil {
+		panic(err)
+	}
+	if err := n.SetKeybinding(n.name, 'a', gocui.ModNone, n.UpdateAvailability("active")); err != nil {
+		panic(err)
+	}
+}
+
+func (n *NodeList) selected() (*Node, error) {
+	v, _ := n.View(n.name)
+	_, cy := v.Cursor()
+	_, oy := v.Origin()
+
+	index := oy + cy
+	length := len(n.Nodes)
+
+	if index >= length {
+		return nil, common.NoNode
+	}
+
+	return n.Nodes[index], nil
+}
+
+func (n *NodeList) Filter(g *gocui.Gui, nv *gocui.View) error {
+	n.NextPanel = n.name
+
+	isReset := false
+	closePanel := func(g *gocui.Gui, v *gocui.View) error {
+		if isReset {
+			n.filter = ""
+		} else {
+			nv.SetCursor(0, 0)
+			n.filter = ReadLine(v, nil)
+		}
+		if v, err := n.View(n.name); err == nil {
+			n.GetNodeList(v)
+		}
+
+		if err := g.DeleteView(v.Name()); err != nil {
+			panic(err)
+		}
+
+		g.DeleteKeybindings(v.Name())
+		n.SwitchPanel(n.name)
+		return nil
+	}
+
+	reset := func(g *gocui.Gui, v *gocui.View) error {
+		isReset = true
+		return closePanel(g, v)
+	}
+
+	if err := n.NewFilterPanel(n, reset, closePanel); err != nil {
+		panic(err)
+	}
+
+	return nil
+}
+
+func (n *NodeList) GetNodeList(v *gocui.View) {
+	v.Clear()
+	n.Nodes = make([]*Node, 0)
+
+	// show the error in the title not to popup the message on each refresh
+	header, err := n.View(NodeListHeaderPanel)
+	if err == nil {
+		header.Title = header.Name()
+	}
+
+	nodes, err := n.Docker.Nodes()
+	if err != nil {
+		if header != nil {
+			header.Title += fmt.Sprintf(" (%s)", err)
+		}
+		return
+	}
+
+	var keys []string
+	tmpMap := make(map[string]*Node)
+
+	for _, node := range nodes {
+		hostname := node.Description.Hostname
+		if n.filter != "" {
+			if strings.Index(strings.ToLower(hostname), strings.ToLower(n.filter)) == -1 {
+				continue
+			}
+		}
+
+		// the host names can be the same
+		key := hostname + node.ID
+
+		tmpMap[key] = &Node{
+			ID:           node.ID,
+			Hostname:     hostname,
+			Role:         node.Spec.Role,
+			Status:       node.Status.State,
+			Availability: node.Spec.Availability,
+			Manager:      node.Manager(),
+			Engine:       node.Description.Engine.EngineVersion,
+		}
+
+		keys = append(keys, key)
+	}
+
+	for _, key := range common.SortKeys(keys) {
+		n.Nodes = append(n.Nodes, tmpMap[key])
+	}
+
+	for _, node := range n.Nodes {
+		common.OutputFormatedLine(v, node)
+	}
+}
+
+func (n *NodeList) Detail(g *gocui.Gui, v *gocui.View) error {
+	n.NextPanel = n.name
+
+	selected, err := n.selected()
+	if err != nil {
+		n.ErrMessage(err.Error(), n.NextPanel)
+		return nil
+	}
+
+	node, err := n.Docker.NodeInfo(selected.ID)
+	if err != nil {
+		n.ErrMessage(err.Error(), n.NextPanel)
+		return nil
+	}
+
+	n.PopupDetailPanel(g, v)
+
+	v, err = g.View(DetailPanel)
+	if err != nil {
+		panic(err)
+	}
+
+	v.Clear()
+	v.SetOrigin(0, 0)
+	v.SetCursor(0, 0)
+
+	fmt.Fprint(v, common.StructToJson(node))
+	return nil
+}
+
+// TaskListPanel shows the running tasks on the node.
+func (n *NodeList) TaskListPanel(g *gocui.Gui, v *gocui.View) error {
+	n.NextPanel = n.name
+
+	selected, err := n.selected()
+	if err != nil {
+		n.ErrMessage(err.Error(), n.NextPanel)
+		return nil
+	}
+
+	maxX, maxY := n.Size()
+	filters := map[string][]string{
+		"node":          {selected.ID},
+		"desired-state": {"running"},
+	}
+	panel := NewTaskList(n.Gui, TaskListPanel, maxX/7, 1, maxX-(maxX/7), maxY-4, selected.Hostname, filters)
+
+	if err := panel.SetView(g); err != nil {
+		n.ErrMessage(err.Error(), n.NextPanel)
+	}
+
+	return nil
+}
+
+// UpdateRole returns the handler to promote or demote the selected node.
+func (n *NodeList) UpdateRole(role, operation string) func(g *gocui.Gui, v *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		n.NextPanel = n.name
+
+		selected, err := n.selected()
+		if err != nil {
+			n.ErrMessage(err.Error(), n.NextPanel)
+			return nil
+		}
+
+		if selected.Role == role {
+			return nil
+		}
+
+		n.ConfirmMessage(fmt.Sprintf("Are you sure you want to %s %s? (y/n)", operation, selected.Hostname), func(g *gocui.Gui, v *gocui.View) error {
+			defer n.Refresh(g, v)
+			defer n.CloseConfirmMessage(g, v)
+
+			if err := n.Docker.UpdateNodeRole(selected.ID, role); err != nil {
+				n.ErrMessage(err.Error(), n.NextPanel)
+				return nil
+			}
+
+			return nil
+		})
+
+		return nil
+	}
+}
+
+// UpdateAvailability returns the handler to change the availability of the selected node.
+// Draining and pausing the node are confirmed because they affect the tasks.
+func (n *NodeList) UpdateAvailability(availability string) func(g *gocui.Gui, v *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		n.NextPanel = n.name
+
+		selected, err := n.selected()
+		if err != nil {
+			n.ErrMessage(err.Error(), n.NextPanel)
+			return nil
+		}
+
+		if selected.Availability == availability {
+			return nil
+		}
+
+		update := func(g *gocui.Gui, v *gocui.View) error {
+			defer n.Refresh(g, v)
+
+			if err := n.Docker.UpdateNodeAvailability(selected.ID, availability); err != nil {
+				n.ErrMessage(err.Error(), n.NextPanel)
+				return nil
+			}
+
+			return nil
+		}
+
+		if availability == "active" {
+			return update(g, v)
+		}
+
+		n.ConfirmMessage(fmt.Sprintf("Are you sure you want to %s %s? (y/n)", availability, selected.Hostname), func(g *gocui.Gui, v *gocui.View) error {
+			defer n.CloseConfirmMessage(g, v)
+			return update(g, v)
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,292 @@
+package panel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/jroimartin/gocui"
+)
+
+var errCanceled = errors.New("canceled")
+
+// Progress shows the progress of pulling or pushing images per layer.
+type Progress struct {
+	*Gui
+	Position
+	name   string
+	title  string
+	prev   string
+	mu     sync.Mutex
+	layers []*layerProgress
+	status string
+	err    string
+	cancel context.CancelFunc
+}
+
+type layerProgress struct {
+	ID       string
+	Status   string
+	Progress string
+	Current  int64
+	Total    int64
+	Done     bool
+}
+
+// jsonMessage is the progress message streamed by the docker daemon.
+type jsonMessage struct {
+	ID             string `json:"id"`
+	Status         string `json:"status"`
+	Progress       string `json:"progress"`
+	ProgressDetail struct {
+		Current int64 `json:"current"`
+		Total   int64 `json:"total"`
+	} `json:"progressDetail"`
+	Stream string `json:"stream"`
+	Error  string `json:"error"`
+}
+
+// NewProgress pops up the progress panel and runs run which writes the json messages to w.
+// done is called after the panel is closed.
+func NewProgress(gui *Gui, name, title string, run func(ctx context.Context, w io.Writer) error, done func(g *gocui.Gui, err error)) *Progress {
+	maxX, maxY := gui.Size()
+	x := maxX / 8
+	y := maxY / 6
+
+	p := &Progress{
+		Gui:      gui,
+		name:     name,
+		title:    title,
+		Position: Position{x, y, maxX - x, maxY - y},
+	}
+
+	if v := gui.CurrentView(); v != nil {
+		p.prev = v.Name()
+	}
+
+	if err := p.SetView(gui.Gui); err != nil {
+		panic(err)
+	}
+
+	p.start(run, done)
+
+	return p
+}
+
+func (p *Progress) Name() string {
+	return p.name
+}
+
+func (p *Progress) SetView(g *gocui.Gui) error {
+	v, err := g.SetView(p.name, p.x, p.y, p.w, p.h)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Title = p.title
+		v.Wrap = false
+		v.FgColor = gocui.ColorCyan
+	}
+
+	p.SetKeyBinding()
+	p.SwitchPanel(p.name)
+
+	return nil
+}
+
+func (p *Progress) SetKeyBinding() {
+	if err := p.SetKeybinding(p.name, gocui.KeyEsc, gocui.ModNone, p.Cancel); err != nil {
+		panic(err)
+	}
+	if err := p.SetKeybinding(p.name, gocui.KeyCtrlC, gocui.ModNone, p.Cancel); err != nil {
+		panic(err)
+	}
+}
+
+func (p *Progress) Refresh(g *gocui.Gui, v *gocui.View) error {
+	return nil
+}
+
+func (p *Progress) start(run func(ctx context.Context, w io.Writer) error, done func(g *gocui.Gui, err error)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	r, w := io.Pipe()
+
+	go func() {
+		w.CloseWithError(run(ctx, w))
+	}()
+
+	go func() {
+		decoder := json.NewDecoder(r)
+
+		var err error
+		for {
+			var msg jsonMessage
+			if err = decoder.Decode(&msg); err != nil {
+				break
+			}
+
+			p.apply(&msg)
+			p.Update(func(g *gocui.Gui) error {
+				p.Display(g)
+				return nil
+			})
+		}
+		r.Close()
+
+		if err == io.EOF {
+			err = nil
+		}
+
+		if ctx.Err() != nil {
+			err = errCanceled
+		} else if err == nil && p.err != "" {
+			err = errors.New(p.err)
+		}
+
+		p.Update(func(g *gocui.Gui) error {
+			p.ClosePanel(g)
+			done(g, err)
+			return nil
+		})
+	}()
+}
+
+func (p *Progress) apply(msg *jsonMessage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if msg.Error != "" {
+		p.err = msg.Error
+		return
+	}
+
+	if msg.ID == "" {
+		p.status = msg.Status
+		return
+	}
+
+	var layer *layerProgress
+	for _, l := range p.layers {
+		if l.ID == msg.ID {
+			layer = l
+		}
+	}
+
+	if layer == nil {
+		layer = &layerProgress{ID: msg.ID}
+		p.layers = append(p.layers, layer)
+	}
+
+	layer.Status = msg.Status
+	layer.Progress = msg.Progress
+
+	switch msg.Status {
+	case "Downloading", "Pushing":
+		layer.Current = msg.ProgressDetail.Current
+		layer.Total = msg.ProgressDetail.Total
+	case "Verifying Checksum", "Download complete", "Extracting", "Pull complete",
+		"Already exists", "Pushed", "Layer already exists":
+		layer.Done = true
+	default:
+		if strings.HasPrefix(msg.Status, "Mounted from") {
+			layer.Done = true
+		}
+	}
+}
+
+// percent returns the total progress of the layers whose size is known.
+func (p *Progress) percent() (int64, int64, int) {
+	var current, total int64
+	for _, layer := range p.layers {
+		if layer.Total == 0 {
+			continue
+		}
+
+		total += layer.Total
+		if layer.Done {
+			current += layer.Total
+		} else {
+			current += layer.Current
+		}
+	}
+
+	if total == 0 {
+		return 0, 0, 0
+	}
+
+	return current, total, int(current * 100 / total)
+}
+
+func (p *Progress) Display(g *gocui.Gui) {
+	v, err := g.View(p.name)
+	if err != nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	v.Clear()
+
+	current, total, percent := p.percent()
+	fmt.Fprintf(v, "%s %3d%% (%s / %s)\n\n", p.title, percent,
+		ParseBytesToString(uint64(current)), ParseBytesToString(uint64(total)))
+
+	for _, layer := range p.layers {
+		fmt.Fprintf(v, "%-12s %-20s %s\n", layer.ID, layer.Status, layer.Progress)
+	}
+
+	if p.status != "" {
+		fmt.Fprintf(v, "\n%s\n", p.status)
+	}
+}
+
+// Cancel cancels the running operation. The panel is closed when it stopped.
+func (p *Progress) Cancel(g *gocui.Gui, v *gocui.View) error {
+	p.cancel()
+	return nil
+}
+
+func (p *Progress) ClosePanel(g *gocui.Gui) {
+	p.DeleteKeybindings(p.name)
+
+	if err := p.DeleteView(p.name); err != nil {
+		panic(err)
+	}
+
+	if p.prev != "" {
+		p.SwitchPanel(p.prev)
+	}
+}
+
+// PullImageWithProgress pulls the image showing the progress.
+func (gui *Gui) PullImageWithProgress(options docker.PullImageOptions, done func(g *gocui.Gui, err error)) {
+	title := fmt.Sprintf("pulling %s:%s", options.Repository, options.Tag)
+
+	NewProgress(gui, ProgressPanel, title, func(ctx context.Context, w io.Writer) error {
+		options.Context = ctx
+		options.OutputStream = w
+		options.RawJSONStream = true
+		return gui.Docker.PullImageWithOptions(options)
+	}, done)
+}
+
+// PushImageWithProgress pushes the image showing the progress.
+func (gui *Gui) PushImageWithProgress(options docker.PushImageOptions, done func(g *gocui.Gui, err error)) {
+	title := fmt.Sprintf("pushing %s:%s", options.Name, options.Tag)
+
+	NewProgress(gui, ProgressPanel, title, func(ctx context.Context, w io.Writer) error {
+		options.Context = ctx
+		options.OutputStream = w
+		options.RawJSONStream = true
+		return gui.Docker.PushImageWithOptions(options)
+	}, done)
+}
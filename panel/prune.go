@@ -0,0 +1,88 @@
+package panel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+	"github.com/skanehira/docui/docker"
+)
+
+// PruneMessage returns the message of the prune result.
+func PruneMessage(kind string, report *docker.PruneReport) string {
+	message := fmt.Sprintf("%d %s deleted, %s reclaimed", len(report.Deleted), kind, ParseSizeToString(report.SpaceReclaimed))
+
+	for _, id := range report.Deleted {
+		id = strings.TrimPrefix(id, "sha256:")
+		if len(id) == 64 {
+			id = id[:12]
+		}
+
+		message += "\n" + id
+	}
+
+	return message
+}
+
+// ConfirmPrune runs the prune after the confirmation and shows the result.
+func (gui *Gui) ConfirmPrune(target, kind string, f func() (*docker.PruneReport, error)) {
+	gui.ConfirmMessage(fmt.Sprintf("Are you sure you want to remove %s? (y/n)", target), func(g *gocui.Gui, v *gocui.View) error {
+		gui.CloseConfirmMessage(g, v)
+		gui.Prune(g, kind, f)
+		return nil
+	})
+}
+
+// Prune runs the prune and shows the result.
+func (gui *Gui) Prune(g *gocui.Gui, kind string, f func() (*docker.PruneReport, error)) {
+	g.Update(func(g *gocui.Gui) error {
+		gui.StateMessage("pruning...")
+
+		g.Update(func(g *gocui.Gui) error {
+			gui.CloseStateMessage()
+
+			report, err := f()
+			if err != nil {
+				gui.ErrMessage(err.Error(), gui.NextPanel)
+				return nil
+			}
+
+			gui.ResultMessage(PruneMessage(kind, report), gui.NextPanel)
+
+			return nil
+		})
+
+		return nil
+	})
+}
+
+// PruneImagesWithFilters removes the dangling images, or all unused images if all is true,
+// with the filters after the confirmation and shows the result.
+func (gui *Gui) PruneImagesWithFilters(g *gocui.Gui, all bool, until, labels string) {
+	filters := docker.NewPruneFilters(until, labels)
+
+	target := "dangling images"
+	if all {
+		target = "all images not used by any containers"
+	}
+	if len(filters) != 0 {
+		target += " matching the filters"
+	}
+
+	gui.ConfirmPrune(target, "images", func() (*docker.PruneReport, error) {
+		return gui.Docker.PruneImagesWithFilters(all, filters)
+	})
+}
+
+// ConfirmPruneBuildCache removes the dangling build cache, or all build cache not in use if all is true,
+// after the confirmation and shows the result.
+func (gui *Gui) ConfirmPruneBuildCache(all bool) {
+	target := "dangling build cache"
+	if all {
+		target = "all build cache not in use"
+	}
+
+	gui.ConfirmPrune(target, "build caches", func() (*docker.PruneReport, error) {
+		return gui.Docker.PruneBuildCache(all)
+	})
+}
@@ -0,0 +1,266 @@
+package panel
+
+import (
+	"fmt"
+
+	"github.com/jroimartin/gocui"
+	"github.com/skanehira/docui/common"
+	"github.com/skanehira/docui/docker"
+)
+
+type RegistryList struct {
+	*Gui
+	Position
+	name           string
+	prev           string
+	Registries     []*docker.Registry
+	Data           map[string]interface{}
+	ClosePanelName string
+	Items          Items
+}
+
+type Registry struct {
+	Registry string `tag:"REGISTRY" len:"min:0.1 max:0.5"`
+	Username string `tag:"USERNAME" len:"min:0.1 max:0.3"`
+	Helper   string `tag:"CREDENTIAL HELPER" len:"min:0.1 max:0.2"`
+}
+
+func NewRegistryList(gui *Gui, name string, x, y, w, h int) *RegistryList {
+	return &RegistryList{
+		Gui:      gui,
+		name:     name,
+		Position: Position{x, y, w, h},
+		Data:     make(map[string]interface{}),
+		Items:    Items{},
+	}
+}
+
+func (r *RegistryList) Name() string {
+	return r.name
+}
+
+func (r *RegistryList) SetView(g *gocui.Gui) error {
+	// set header panel
+	if v, err := g.SetView(RegistryListHeaderPanel, r.x, r.y, r.w, r.h); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Wrap = true
+		v.Frame = true
+		v.Title = v.Name()
+		v.FgColor = gocui.AttrBold | gocui.ColorWhite
+		common.OutputFormatedHeader(v, &Registry{})
+	}
+
+	// set scroll panel
+	v, err := g.SetView(r.name, r.x, r.y+1, r.w, r.h)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Wrap = true
+		v.FgColor = gocui.ColorGreen
+		v.SelBgColor = gocui.ColorWhite
+		v.SelFgColor = gocui.ColorBlack | gocui.AttrBold
+		v.SetOrigin(0, 0)
+		v.SetCursor(0, 0)
+	}
+
+	r.GetRegistryList(v)
+	r.SetKeyBinding()
+	r.SwitchPanel(r.name)
+
+	return nil
+}
+
+func (r *RegistryList) SetKeyBinding() {
+	if err := r.SetKeybinding(r.name, 'j', gocui.ModNone, CursorDown); err != nil {
+		panic(err)
+	}
+	if err := r.SetKeybinding(r.name, 'k', gocui.ModNone, CursorUp); err != nil {
+		panic(err)
+	}
+	if err := r.SetKeybinding(r.name, gocui.KeyEnter, gocui.ModNone, r.LoginPanel); err != nil {
+		panic(err)
+	}
+	if err := r.SetKeybinding(r.name, 'd', gocui.ModNone, r.Logout); err != nil {
+		panic(err)
+	}
+	if err := r.SetKeybinding(r.name, gocui.KeyEsc, gocui.ModNone, r.CloseRegistryPanel); err != nil {
+		panic(err)
+	}
+	if err := r.SetKeybinding(r.name, 'q', gocui.ModNone, r.CloseRegistryPanel); err != nil {
+		panic(err)
+	}
+	if err := r.SetKeybinding(r.name, gocui.KeyCtrlQ, gocui.ModNone, r.quit); err != nil {
+		panic(err)
+	}
+}
+
+func (r *RegistryList) Refresh(g *gocui.Gui, v *gocui.View) error {
+	registries, err := docker.Registries()
+	if err != nil {
+		return err
+	}
+
+	r.Registries = registries
+
+	v, err = g.View(r.name)
+	if err != nil {
+		return err
+	}
+
+	r.GetRegistryList(v)
+
+	return nil
+}
+
+func (r *RegistryList) GetRegistryList(v *gocui.View) {
+	v.Clear()
+
+	for _, registry := range r.Registries {
+		common.OutputFormatedLine(v, &Registry{
+			Registry: registry.ServerAddress,
+			Username: registry.Username,
+			Helper:   registry.Helper,
+		})
+	}
+}
+
+func (r *RegistryList) selected() *docker.Registry {
+	v, _ := r.View(r.name)
+	_, cy := v.Cursor()
+	_, oy := v.Origin()
+
+	index := oy + cy
+	if index >= len(r.Registries) {
+		return nil
+	}
+
+	return r.Registries[index]
+}
+
+func (r *RegistryList) LoginPanel(g *gocui.Gui, v *gocui.View) error {
+	r.NextPanel = r.name
+
+	r.Data = map[string]interface{}{
+		"Registry": docker.DefaultRegistry,
+	}
+
+	if registry := r.selected(); registry != nil {
+		r.Data["Registry"] = registry.ServerAddress
+		r.Data["Username"] = registry.Username
+	}
+
+	maxX, maxY := r.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 8
+
+	r.ClosePanelName = LoginPanel
+	r.Items = r.NewLoginItems(x, y, w, h)
+
+	handlers := Handlers{
+		gocui.KeyEnter: r.Login,
+	}
+
+	NewInput(r.Gui, LoginPanel, x, y, w, h, r.Items, r.Data, handlers)
+	return nil
+}
+
+func (r *RegistryList) NewLoginItems(ix, iy, iw, ih int) Items {
+	names := []string{
+		"Registry",
+		"Username",
+		"Password",
+	}
+
+	return NewItems(names, ix, iy, iw, ih, 10)
+}
+
+func (r *RegistryList) Login(g *gocui.Gui, v *gocui.View) error {
+	data, err := r.GetItemsToMap(r.Items)
+	if err != nil {
+		r.ClosePanel(g, v)
+		r.ErrMessage(err.Error(), r.NextPanel)
+		return nil
+	}
+
+	if data["Registry"] == "" || data["Username"] == "" {
+		return nil
+	}
+
+	g.Update(func(g *gocui.Gui) error {
+		r.ClosePanel(g, v)
+		r.StateMessage("logging in...")
+
+		g.Update(func(g *gocui.Gui) error {
+			r.CloseStateMessage()
+
+			if err := r.Docker.Login(data["Registry"], data["Username"], data["Password"]); err != nil {
+				r.ErrMessage(err.Error(), r.NextPanel)
+				return nil
+			}
+
+			if err := r.Refresh(g, v); err != nil {
+				r.ErrMessage(err.Error(), r.NextPanel)
+				return nil
+			}
+
+			r.SwitchPanel(r.NextPanel)
+
+			return nil
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
+func (r *RegistryList) Logout(g *gocui.Gui, v *gocui.View) error {
+	r.NextPanel = r.name
+
+	registry := r.selected()
+	if registry == nil {
+		return nil
+	}
+
+	r.ConfirmMessage(fmt.Sprintf("Are you sure you want to logout from %s? (y/n)", registry.ServerAddress), func(g *gocui.Gui, v *gocui.View) error {
+		defer r.Refresh(g, v)
+		r.CloseConfirmMessage(g, v)
+
+		if err := docker.Logout(registry.ServerAddress); err != nil {
+			r.ErrMessage(err.Error(), r.NextPanel)
+			return nil
+		}
+
+		return nil
+	})
+
+	return nil
+}
+
+func (r *RegistryList) ClosePanel(g *gocui.Gui, v *gocui.View) error {
+	return r.Panels[r.ClosePanelName].(*Input).ClosePanel(g, v)
+}
+
+func (r *RegistryList) CloseRegistryPanel(g *gocui.Gui, v *gocui.View) error {
+	r.DeleteKeybindings(r.name)
+	if err := r.DeleteView(r.name); err != nil {
+		return err
+	}
+
+	if err := r.DeleteView(RegistryListHeaderPanel); err != nil {
+		return err
+	}
+
+	r.NextPanel = r.prev
+	r.SwitchPanel(r.NextPanel)
+
+	return nil
+}
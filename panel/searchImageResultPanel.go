@@ -131,31 +131,23 @@ func (s *SearchImageResult) selected() *SearchResult {
 }
 
 func (s *SearchImageResult) PullImage(g *gocui.Gui, v *gocui.View) error {
-	g.Update(func(g *gocui.Gui) error {
-		s.StateMessage("image pulling...")
-
-		g.Update(func(g *gocui.Gui) error {
-			defer s.CloseStateMessage()
-
-			options := docker.PullImageOptions{
-				Repository: s.getImageName(),
-				Tag:        "latest",
-			}
-			if err := s.Docker.PullImageWithOptions(options); err != nil {
-				s.ErrMessage(err.Error(), s.name)
-				return nil
-			}
-
-			s.ClosePanel(g, v)
-			s.CloseSearchPanel()
+	options := docker.PullImageOptions{
+		Repository: s.getImageName(),
+		Tag:        "latest",
+	}
 
-			s.SwitchPanel(ImageListPanel)
+	s.PullImageWithProgress(options, func(g *gocui.Gui, err error) {
+		if err != nil {
+			s.ErrMessage(err.Error(), s.name)
+			return
+		}
 
-			return nil
-		})
+		s.ClosePanel(g, v)
+		s.CloseSearchPanel()
 
-		return nil
+		s.SwitchPanel(ImageListPanel)
 	})
+
 	return nil
 }
 
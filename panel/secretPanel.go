@@ -0,0 +1,414 @@
+package panel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+	"github.com/skanehira/docui/common"
+	"github.com/skanehira/docui/docker"
+)
+
+// SecretList is the list of the secrets or the configs of the swarm.
+// They have the same metadata, so the panel is shared with the functions of the kind.
+type SecretList struct {
+	*Gui
+	name string
+	Position
+	kind           string
+	headerName     string
+	createName     string
+	Secrets        []*Secret
+	Data           map[string]interface{}
+	ClosePanelName string
+	Items          Items
+	filter         string
+
+	// the methods of the kind, which are called with the current client
+	empty      error
+	list       func(d *docker.Docker) ([]*docker.SwarmObject, error)
+	info       func(d *docker.Docker, id string) (map[string]interface{}, error)
+	create     func(d *docker.Docker, data map[string]string) error
+	remove     func(d *docker.Docker, id string) error
+	references func(d *docker.Docker, id string) ([]string, error)
+}
+
+type Secret struct {
+	ID      string `tag:"ID" len:"min:0.1 max:0.1"`
+	Name    string `tag:"NAME" len:"min:0.1 max:0.2"`
+	Labels  string `tag:"LABELS" len:"min:0.1 max:0.3"`
+	Created string `tag:"CREATED" len:"min:0.1 max:0.2"`
+	Updated string `tag:"UPDATED" len:"min:0.1 max:0.2"`
+}
+
+func NewSecretList(gui *Gui, name string, x, y, w, h int) *SecretList {
+	return &SecretList{
+		Gui:        gui,
+		name:       name,
+		Position:   Position{x, y, w, h},
+		kind:       "secret",
+		headerName: SecretListHeaderPanel,
+		createName: CreateSecretPanel,
+		Data:       make(map[string]interface{}),
+		Items:      Items{},
+		empty:      common.NoSecret,
+		list:       (*docker.Docker).Secrets,
+		info:       (*docker.Docker).SecretInfo,
+		create:     (*docker.Docker).CreateSecret,
+		remove:     (*docker.Docker).RemoveSecret,
+		references: (*docker.Docker).SecretReferences,
+	}
+}
+
+func NewConfigList(gui *Gui, name string, x, y, w, h int) *SecretList {
+	return &SecretList{
+		Gui:        gui,
+		name:       name,
+		Position:   Position{x, y, w, h},
+		kind:       "config",
+		headerName: ConfigListHeaderPanel,
+		createName: CreateConfigPanel,
+		Data:       make(map[string]interface{}),
+		Items:      Items{},
+		empty:      common.NoConfig,
+		list:       (*docker.Docker).Configs,
+		info:       (*docker.Docker).ConfigInfo,
+		create:     (*docker.Docker).CreateConfig,
+		remove:     (*docker.Docker).RemoveConfig,
+		references: (*docker.Docker).ConfigReferences,
+	}
+}
+
+func (s *SecretList) Name() string {
+	return s.name
+}
+
+func (s *SecretList) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+	switch {
+	case ch != 0 && mod == 0:
+		v.EditWrite(ch)
+	case key == gocui.KeySpace:
+		v.EditWrite(' ')
+	case key == gocui.KeyBackspace || key == gocui.KeyBackspace2:
+		v.EditDelete(true)
+	case key == gocui.KeyArrowLeft:
+		v.MoveCursor(-1, 0, false)
+		return
+	case key == gocui.KeyArrowRight:
+		v.MoveCursor(+1, 0, false)
+		return
+	}
+
+	s.filter = ReadLine(v, nil)
+
+	if v, err := s.View(s.name); err == nil {
+		s.GetSecretList(v)
+	}
+}
+
+func (s *SecretList) SetView(g *gocui.Gui) error {
+	// set header panel
+	if v, err := g.SetView(s.headerName, s.x, s.y, s.w, s.h); err != nil {
+		if err != gocui.ErrUnknownView {
+			panic(err)
+		}
+
+		v.Wrap = true
+		v.Frame = true
+		v.Title = v.Name()
+		v.FgColor = gocui.AttrBold | gocui.ColorWhite
+		common.OutputFormatedHeader(v, &Secret{})
+	}
+
+	// set scroll panel
+	v, err := g.SetView(s.name, s.x, s.y+1, s.w, s.h)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Wrap = true
+		v.FgColor = gocui.ColorMagenta
+		v.SelBgColor = gocui.ColorWhite
+		v.SelFgColor = gocui.ColorBlack | gocui.AttrBold
+		v.SetOrigin(0, 0)
+		v.SetCursor(0, 0)
+
+		s.GetSecretList(v)
+	}
+
+	s.SetKeyBinding()
+
+	return nil
+}
+
+func (s *SecretList) Refresh(g *gocui.Gui, v *gocui.View) error {
+	s.Update(func(g *gocui.Gui) error {
+		v, err := s.View(s.name)
+		if err != nil {
+			panic(err)
+		}
+		s.GetSecretList(v)
+		return nil
+	})
+
+	return nil
+}
+
+func (s *SecretList) SetKeyBinding() {
+	s.SetKeyBindingToPanel(s.name)
+
+	if err := s.SetKeybinding(s.name, 'j', gocui.ModNone, CursorDown); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, 'k', gocui.ModNone, CursorUp); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, gocui.KeyCtrlR, gocui.ModNone, s.Refresh); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, 'f', gocui.ModNone, s.Filter); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, 'o', gocui.ModNone, s.Detail); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, gocui.KeyEnter, gocui.ModNone, s.Detail); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, 'c', gocui.ModNone, s.CreateSecretPanel); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, 'd', gocui.ModNone, s.RemoveSecret); err != nil {
+		panic(err)
+	}
+}
+
+func (s *SecretList) selected() (*Secret, error) {
+	v, _ := s.View(s.name)
+	_, cy := v.Cursor()
+	_, oy := v.Origin()
+
+	index := oy + cy
+	length := len(s.Secrets)
+
+	if index >= length {
+		return nil, s.empty
+	}
+
+	return s.Secrets[index], nil
+}
+
+func (s *SecretList) Filter(g *gocui.Gui, sv *gocui.View) error {
+	s.NextPanel = s.name
+
+	isReset := false
+	closePanel := func(g *gocui.Gui, v *gocui.View) error {
+		if isReset {
+			s.filter = ""
+		} else {
+			sv.SetCursor(0, 0)
+			s.filter = ReadLine(v, nil)
+		}
+		if v, err := s.View(s.name); err == nil {
+			s.GetSecretList(v)
+		}
+
+		if err := g.DeleteView(v.Name()); err != nil {
+			panic(err)
+		}
+
+		g.DeleteKeybindings(v.Name())
+		s.SwitchPanel(s.name)
+		return nil
+	}
+
+	reset := func(g *gocui.Gui, v *gocui.View) error {
+		isReset = true
+		return closePanel(g, v)
+	}
+
+	if err := s.NewFilterPanel(s, reset, closePanel); err != nil {
+		panic(err)
+	}
+
+	return nil
+}
+
+func (s *SecretList) ClosePanel(g *gocui.Gui, v *gocui.View) error {
+	return s.Panels[s.ClosePanelName].(*Input).ClosePanel(g, v)
+}
+
+func (s *SecretList) GetSecretList(v *gocui.View) {
+	v.Clear()
+	s.Secrets = make([]*Secret, 0)
+
+	// show the error in the title not to popup the message on each refresh
+	header, err := s.View(s.headerName)
+	if err == nil {
+		header.Title = header.Name()
+	}
+
+	secrets, err := s.list(s.Docker)
+	if err != nil {
+		if header != nil {
+			header.Title += fmt.Sprintf(" (%s)", err)
+		}
+		return
+	}
+
+	var keys []string
+	tmpMap := make(map[string]*Secret)
+
+	for _, secret := range secrets {
+		if s.filter != "" {
+			if strings.Index(strings.ToLower(secret.Spec.Name), strings.ToLower(s.filter)) == -1 {
+				continue
+			}
+		}
+
+		tmpMap[secret.Spec.Name] = &Secret{
+			ID:      secret.ID,
+			Name:    secret.Spec.Name,
+			Labels:  secret.Labels(),
+			Created: ParseDateToString(secret.CreatedAt.Unix()),
+			Updated: ParseDateToString(secret.UpdatedAt.Unix()),
+		}
+
+		keys = append(keys, secret.Spec.Name)
+	}
+
+	for _, key := range common.SortKeys(keys) {
+		s.Secrets = append(s.Secrets, tmpMap[key])
+	}
+
+	for _, secret := range s.Secrets {
+		common.OutputFormatedLine(v, secret)
+	}
+}
+
+func (s *SecretList) Detail(g *gocui.Gui, v *gocui.View) error {
+	s.NextPanel = s.name
+
+	selected, err := s.selected()
+	if err != nil {
+		s.ErrMessage(err.Error(), s.NextPanel)
+		return nil
+	}
+
+	secret, err := s.info(s.Docker, selected.ID)
+	if err != nil {
+		s.ErrMessage(err.Error(), s.NextPanel)
+		return nil
+	}
+
+	s.PopupDetailPanel(g, v)
+
+	v, err = g.View(DetailPanel)
+	if err != nil {
+		panic(err)
+	}
+
+	v.Clear()
+	v.SetOrigin(0, 0)
+	v.SetCursor(0, 0)
+
+	fmt.Fprint(v, common.StructToJson(secret))
+	return nil
+}
+
+func (s *SecretList) CreateSecretPanel(g *gocui.Gui, v *gocui.View) error {
+	s.NextPanel = s.name
+
+	s.Data = map[string]interface{}{}
+
+	maxX, maxY := s.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 10
+
+	s.ClosePanelName = s.createName
+	s.Items = NewItems([]string{"Name", "File", "Value", "Labels"}, x, y, w, h, 12)
+
+	handlers := Handlers{
+		gocui.KeyEnter: s.CreateSecret,
+	}
+
+	NewInput(s.Gui, s.createName, x, y, w, h, s.Items, s.Data, handlers)
+	return nil
+}
+
+func (s *SecretList) CreateSecret(g *gocui.Gui, v *gocui.View) error {
+	data, err := s.GetItemsToMap(s.Items)
+	if err != nil {
+		s.ClosePanel(g, v)
+		s.ErrMessage(err.Error(), s.NextPanel)
+		return nil
+	}
+
+	if data["Name"] == "" {
+		return nil
+	}
+
+	g.Update(func(g *gocui.Gui) error {
+		s.ClosePanel(g, v)
+		s.StateMessage(s.kind + " creating...")
+
+		g.Update(func(g *gocui.Gui) error {
+			defer s.Refresh(g, v)
+			defer s.CloseStateMessage()
+
+			if err := s.create(s.Docker, data); err != nil {
+				s.ErrMessage(err.Error(), s.NextPanel)
+				return nil
+			}
+
+			s.SwitchPanel(s.NextPanel)
+
+			return nil
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
+// RemoveSecret removes the selected secret or config
+// unless the services still use it.
+func (s *SecretList) RemoveSecret(g *gocui.Gui, v *gocui.View) error {
+	s.NextPanel = s.name
+
+	selected, err := s.selected()
+	if err != nil {
+		s.ErrMessage(err.Error(), s.NextPanel)
+		return nil
+	}
+
+	services, err := s.references(s.Docker, selected.ID)
+	if err != nil {
+		s.ErrMessage(err.Error(), s.NextPanel)
+		return nil
+	}
+
+	if len(services) > 0 {
+		s.ErrMessage(fmt.Sprintf("%s %s is used by services: %s", s.kind, selected.Name, strings.Join(services, ", ")), s.NextPanel)
+		return nil
+	}
+
+	s.ConfirmMessage(fmt.Sprintf("Are you sure you want to remove %s? (y/n)", selected.Name), func(g *gocui.Gui, v *gocui.View) error {
+		defer s.Refresh(g, v)
+		defer s.CloseConfirmMessage(g, v)
+
+		if err := s.remove(s.Docker, selected.ID); err != nil {
+			s.ErrMessage(err.Error(), s.NextPanel)
+			return nil
+		}
+
+		return nil
+	})
+
+	return nil
+}
@@ -0,0 +1,519 @@
+package panel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+	"github.com/skanehira/docui/common"
+)
+
+type ServiceList struct {
+	*Gui
+	name string
+	Position
+	Services       []*Service
+	Data           map[string]interface{}
+	ClosePanelName string
+	Items          Items
+	filter         string
+}
+
+type Service struct {
+	ID       string `tag:"ID" len:"min:0.1 max:0.1"`
+	Name     string `tag:"NAME" len:"min:0.1 max:0.2"`
+	Mode     string `tag:"MODE" len:"min:0.1 max:0.1"`
+	Replicas string `tag:"REPLICAS" len:"min:0.1 max:0.1"`
+	Image    string `tag:"IMAGE" len:"min:0.1 max:0.3"`
+	Ports    string `tag:"PORTS" len:"min:0.1 max:0.2"`
+}
+
+func NewServiceList(gui *Gui, name string, x, y, w, h int) *ServiceList {
+	return &ServiceList{
+		Gui:      gui,
+		name:     name,
+		Position: Position{x, y, w, h},
+		Data:     make(map[string]interface{}),
+		Items:    Items{},
+	}
+}
+
+func (s *ServiceList) Name() string {
+	return s.name
+}
+
+func (s *ServiceList) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+	switch {
+	case ch != 0 && mod == 0:
+		v.EditWrite(ch)
+	case key == gocui.KeySpace:
+		v.EditWrite(' ')
+	case key == gocui.KeyBackspace || key == gocui.KeyBackspace2:
+		v.EditDelete(true)
+	case key == gocui.KeyArrowLeft:
+		v.MoveCursor(-1, 0, false)
+		return
+	case key == gocui.KeyArrowRight:
+		v.MoveCursor(+1, 0, false)
+		return
+	}
+
+	s.filter = ReadLine(v, nil)
+
+	if v, err := s.View(s.name); err == nil {
+		s.GetServiceList(v)
+	}
+}
+
+func (s *ServiceList) SetView(g *gocui.Gui) error {
+	// set header panel
+	if v, err := g.SetView(ServiceListHeaderPanel, s.x, s.y, s.w, s.h); err != nil {
+		if err != gocui.ErrUnknownView {
+			panic(err)
+		}
+
+		v.Wrap = true
+		v.Frame = true
+		v.Title = v.Name()
+		v.FgColor = gocui.AttrBold | gocui.ColorWhite
+		common.OutputFormatedHeader(v, &Service{})
+	}
+
+	// set scroll panel
+	v, err := g.SetView(s.name, s.x, s.y+1, s.w, s.h)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Wrap = true
+		v.FgColor = gocui.ColorBlue
+		v.SelBgColor = gocui.ColorWhite
+		v.SelFgColor = gocui.ColorBlack | gocui.AttrBold
+		v.SetOrigin(0, 0)
+		v.SetCursor(0, 0)
+
+		s.GetServiceList(v)
+	}
+
+	s.SetKeyBinding()
+
+	return nil
+}
+
+func (s *ServiceList) Refresh(g *gocui.Gui, v *gocui.View) error {
+	s.Update(func(g *gocui.Gui) error {
+		v, err := s.View(s.name)
+		if err != nil {
+			panic(err)
+		}
+		s.GetServiceList(v)
+		return nil
+	})
+
+	return nil
+}
+
+func (s *ServiceList) SetKeyBinding() {
+	s.SetKeyBindingToPanel(s.name)
+
+	if err := s.SetKeybinding(s.name, 'j', gocui.ModNone, CursorDown); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, 'k', gocui.ModNone, CursorUp); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, gocui.KeyCtrlR, gocui.ModNone, s.Refresh); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, 'f', gocui.ModNone, s.Filter); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, 'o', gocui.ModNone, s.Detail); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, gocui.KeyEnter, gocui.ModNone, s.Detail); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, 't', gocui.ModNone, s.TaskListPanel); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, 's', gocui.ModNone, s.ScaleServicePanel); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, 'u', gocui.ModNone, s.UpdateServicePanel); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, 'R', gocui.ModNone, s.RollbackService); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, 'd', gocui.ModNone, s.RemoveService); err != nil {
+		panic(err)
+	}
+}
+
+func (s *ServiceList) selected() (*Service, error) {
+	v, _ := s.View(s.name)
+	_, cy := v.Cursor()
+	_, oy := v.Origin()
+
+	index := oy + cy
+	length := len(s.Services)
+
+	if index >= length {
+		return nil, common.NoService
+	}
+
+	return s.Services[index], nil
+}
+
+func (s *ServiceList) Filter(g *gocui.Gui, sv *gocui.View) error {
+	s.NextPanel = s.name
+
+	isReset := false
+	closePanel := func(g *gocui.Gui, v *gocui.View) error {
+		if isReset {
+			s.filter = ""
+		} else {
+			sv.SetCursor(0, 0)
+			s.filter = ReadLine(v, nil)
+		}
+		if v, err := s.View(s.name); err == nil {
+			s.GetServiceList(v)
+		}
+
+		if err := g.DeleteView(v.Name()); err != nil {
+			panic(err)
+		}
+
+		g.DeleteKeybindings(v.Name())
+		s.SwitchPanel(s.name)
+		return nil
+	}
+
+	reset := func(g *gocui.Gui, v *gocui.View) error {
+		isReset = true
+		return closePanel(g, v)
+	}
+
+	if err := s.NewFilterPanel(s, reset, closePanel); err != nil {
+		panic(err)
+	}
+
+	return nil
+}
+
+func (s *ServiceList) ClosePanel(g *gocui.Gui, v *gocui.View) error {
+	return s.Panels[s.ClosePanelName].(*Input).ClosePanel(g, v)
+}
+
+func (s *ServiceList) GetServiceList(v *gocui.View) {
+	v.Clear()
+	s.Services = make([]*Service, 0)
+
+	// show the error in the title not to popup the message on each refresh
+	header, err := s.View(ServiceListHeaderPanel)
+	if err == nil {
+		header.Title = header.Name()
+	}
+
+	services, err := s.Docker.Services()
+	if err != nil {
+		if header != nil {
+			header.Title += fmt.Sprintf(" (%s)", err)
+		}
+		return
+	}
+
+	var keys []string
+	tmpMap := make(map[string]*Service)
+
+	for _, service := range services {
+		if s.filter != "" {
+			if strings.Index(strings.ToLower(service.Spec.Name), strings.ToLower(s.filter)) == -1 {
+				continue
+			}
+		}
+
+		var replicas string
+		if status := service.ServiceStatus; status != nil {
+			replicas = fmt.Sprintf("%d/%d", status.RunningTasks, status.DesiredTasks)
+		}
+
+		if update := service.UpdateStatus; update != nil && update.State != "" && update.State != "completed" {
+			replicas += fmt.Sprintf(" (%s)", strings.Replace(update.State, "_", " ", -1))
+		}
+
+		tmpMap[service.Spec.Name] = &Service{
+			ID:       service.ID,
+			Name:     service.Spec.Name,
+			Mode:     service.Mode(),
+			Replicas: replicas,
+			Image:    service.Spec.TaskTemplate.ContainerSpec.Image,
+			Ports:    service.Ports(),
+		}
+
+		keys = append(keys, service.Spec.Name)
+	}
+
+	for _, key := range common.SortKeys(keys) {
+		s.Services = append(s.Services, tmpMap[key])
+	}
+
+	for _, service := range s.Services {
+		common.OutputFormatedLine(v, service)
+	}
+}
+
+func (s *ServiceList) Detail(g *gocui.Gui, v *gocui.View) error {
+	s.NextPanel = s.name
+
+	selected, err := s.selected()
+	if err != nil {
+		s.ErrMessage(err.Error(), s.NextPanel)
+		return nil
+	}
+
+	service, err := s.Docker.ServiceInfo(selected.ID)
+	if err != nil {
+		s.ErrMessage(err.Error(), s.NextPanel)
+		return nil
+	}
+
+	s.PopupDetailPanel(g, v)
+
+	v, err = g.View(DetailPanel)
+	if err != nil {
+		panic(err)
+	}
+
+	v.Clear()
+	v.SetOrigin(0, 0)
+	v.SetCursor(0, 0)
+
+	fmt.Fprint(v, common.StructToJson(service))
+	return nil
+}
+
+func (s *ServiceList) TaskListPanel(g *gocui.Gui, v *gocui.View) error {
+	s.NextPanel = s.name
+
+	selected, err := s.selected()
+	if err != nil {
+		s.ErrMessage(err.Error(), s.NextPanel)
+		return nil
+	}
+
+	maxX, maxY := s.Size()
+	filters := map[string][]string{"service": {selected.ID}}
+	panel := NewTaskList(s.Gui, TaskListPanel, maxX/7, 1, maxX-(maxX/7), maxY-4, selected.Name, filters)
+
+	if err := panel.SetView(g); err != nil {
+		s.ErrMessage(err.Error(), s.NextPanel)
+	}
+
+	return nil
+}
+
+func (s *ServiceList) ScaleServicePanel(g *gocui.Gui, v *gocui.View) error {
+	s.NextPanel = s.name
+
+	selected, err := s.selected()
+	if err != nil {
+		s.ErrMessage(err.Error(), s.NextPanel)
+		return nil
+	}
+
+	s.Data = map[string]interface{}{
+		"Service": selected.Name,
+	}
+
+	// fill the desired replicas of `running/desired`
+	if replicas := strings.Fields(selected.Replicas); len(replicas) > 0 {
+		if i := strings.Index(replicas[0], "/"); i != -1 {
+			s.Data["Replicas"] = replicas[0][i+1:]
+		}
+	}
+
+	maxX, maxY := s.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 6
+
+	s.ClosePanelName = ScaleServicePanel
+	s.Items = NewItems([]string{"Service", "Replicas"}, x, y, w, h, 12)
+
+	handlers := Handlers{
+		gocui.KeyEnter: s.ScaleService,
+	}
+
+	NewInput(s.Gui, ScaleServicePanel, x, y, w, h, s.Items, s.Data, handlers)
+	return nil
+}
+
+func (s *ServiceList) ScaleService(g *gocui.Gui, v *gocui.View) error {
+	data, err := s.GetItemsToMap(s.Items)
+	if err != nil {
+		s.ClosePanel(g, v)
+		s.ErrMessage(err.Error(), s.NextPanel)
+		return nil
+	}
+
+	if data["Service"] == "" || data["Replicas"] == "" {
+		return nil
+	}
+
+	replicas, err := strconv.ParseUint(data["Replicas"], 10, 64)
+	if err != nil {
+		s.ClosePanel(g, v)
+		s.ErrMessage(fmt.Sprintf("invalid replicas: %s", data["Replicas"]), s.NextPanel)
+		return nil
+	}
+
+	g.Update(func(g *gocui.Gui) error {
+		s.ClosePanel(g, v)
+		s.StateMessage("service scaling...")
+
+		g.Update(func(g *gocui.Gui) error {
+			defer s.Refresh(g, v)
+			defer s.CloseStateMessage()
+
+			if err := s.Docker.ScaleService(data["Service"], replicas); err != nil {
+				s.ErrMessage(err.Error(), s.NextPanel)
+				return nil
+			}
+
+			s.SwitchPanel(s.NextPanel)
+
+			return nil
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
+func (s *ServiceList) UpdateServicePanel(g *gocui.Gui, v *gocui.View) error {
+	s.NextPanel = s.name
+
+	selected, err := s.selected()
+	if err != nil {
+		s.ErrMessage(err.Error(), s.NextPanel)
+		return nil
+	}
+
+	// the image is pinned to the digest by the daemon
+	image := selected.Image
+	if i := strings.Index(image, "@"); i != -1 {
+		image = image[:i]
+	}
+
+	s.Data = map[string]interface{}{
+		"Service": selected.Name,
+		"Image":   image,
+	}
+
+	maxX, maxY := s.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 6
+
+	s.ClosePanelName = UpdateServicePanel
+	s.Items = NewItems([]string{"Service", "Image"}, x, y, w, h, 12)
+
+	handlers := Handlers{
+		gocui.KeyEnter: s.UpdateService,
+	}
+
+	NewInput(s.Gui, UpdateServicePanel, x, y, w, h, s.Items, s.Data, handlers)
+	return nil
+}
+
+func (s *ServiceList) UpdateService(g *gocui.Gui, v *gocui.View) error {
+	data, err := s.GetItemsToMap(s.Items)
+	if err != nil {
+		s.ClosePanel(g, v)
+		s.ErrMessage(err.Error(), s.NextPanel)
+		return nil
+	}
+
+	if data["Service"] == "" || data["Image"] == "" {
+		return nil
+	}
+
+	g.Update(func(g *gocui.Gui) error {
+		s.ClosePanel(g, v)
+		s.StateMessage("service updating...")
+
+		g.Update(func(g *gocui.Gui) error {
+			defer s.Refresh(g, v)
+			defer s.CloseStateMessage()
+
+			if err := s.Docker.UpdateServiceImage(data["Service"], data["Image"]); err != nil {
+				s.ErrMessage(err.Error(), s.NextPanel)
+				return nil
+			}
+
+			s.SwitchPanel(s.NextPanel)
+
+			return nil
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
+func (s *ServiceList) RollbackService(g *gocui.Gui, v *gocui.View) error {
+	s.NextPanel = s.name
+
+	selected, err := s.selected()
+	if err != nil {
+		s.ErrMessage(err.Error(), s.NextPanel)
+		return nil
+	}
+
+	s.ConfirmMessage(fmt.Sprintf("Are you sure you want to rollback %s? (y/n)", selected.Name), func(g *gocui.Gui, v *gocui.View) error {
+		defer s.Refresh(g, v)
+		defer s.CloseConfirmMessage(g, v)
+
+		if err := s.Docker.RollbackService(selected.ID); err != nil {
+			s.ErrMessage(err.Error(), s.NextPanel)
+			return nil
+		}
+
+		return nil
+	})
+
+	return nil
+}
+
+func (s *ServiceList) RemoveService(g *gocui.Gui, v *gocui.View) error {
+	s.NextPanel = s.name
+
+	selected, err := s.selected()
+	if err != nil {
+		s.ErrMessage(err.Error(), s.NextPanel)
+		return nil
+	}
+
+	s.ConfirmMessage(fmt.Sprintf("Are you sure you want to remove %s? (y/n)", selected.Name), func(g *gocui.Gui, v *gocui.View) error {
+		defer s.Refresh(g, v)
+		defer s.CloseConfirmMessage(g, v)
+
+		if err := s.Docker.RemoveService(selected.ID); err != nil {
+			s.ErrMessage(err.Error(), s.NextPanel)
+			return nil
+		}
+
+		return nil
+	})
+
+	return nil
+}
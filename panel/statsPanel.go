@@ -0,0 +1,423 @@
+package panel
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/jroimartin/gocui"
+	"github.com/skanehira/docui/common"
+)
+
+const (
+	// number of stats kept for the sparkline
+	statsHistorySize = 60
+)
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+type StatsList struct {
+	*Gui
+	Position
+	name     string
+	Stats    []*ContainerStats
+	selectID string
+	history  map[string]*statsHistory
+	cancel   context.CancelFunc
+}
+
+type ContainerStats struct {
+	ID            string `tag:"ID" len:"min:0.1 max:0.1"`
+	Name          string `tag:"NAME" len:"min:0.1 max:0.2"`
+	CPU           string `tag:"CPU %" len:"min:0.1 max:0.1"`
+	Memory        string `tag:"MEM USAGE / LIMIT" len:"min:0.1 max:0.2"`
+	MemoryPercent string `tag:"MEM %" len:"min:0.1 max:0.1"`
+	NetIO         string `tag:"NET I/O" len:"min:0.1 max:0.15"`
+	BlockIO       string `tag:"BLOCK I/O" len:"min:0.1 max:0.15"`
+}
+
+type statsHistory struct {
+	cpu    []float64
+	memory []float64
+}
+
+func (h *statsHistory) add(cpu, memory float64) {
+	h.cpu = append(h.cpu, cpu)
+	h.memory = append(h.memory, memory)
+
+	if len(h.cpu) > statsHistorySize {
+		h.cpu = h.cpu[1:]
+		h.memory = h.memory[1:]
+	}
+}
+
+func NewStatsList(gui *Gui, name string, x, y, w, h int, selectID string) *StatsList {
+	return &StatsList{
+		Gui:      gui,
+		name:     name,
+		Position: Position{x, y, w, h},
+		selectID: selectID,
+		history:  make(map[string]*statsHistory),
+	}
+}
+
+func (s *StatsList) Name() string {
+	return s.name
+}
+
+func (s *StatsList) SetView(g *gocui.Gui) error {
+	graphY := s.h - 4
+
+	// set header panel
+	if v, err := g.SetView(StatsHeaderPanel, s.x, s.y, s.w, graphY-1); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Wrap = true
+		v.Frame = true
+		v.Title = v.Name()
+		v.FgColor = gocui.AttrBold | gocui.ColorWhite
+		common.OutputFormatedHeader(v, &ContainerStats{})
+	}
+
+	// set scroll panel
+	if v, err := g.SetView(s.name, s.x, s.y+1, s.w, graphY-1); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Wrap = true
+		v.FgColor = gocui.ColorGreen
+		v.SelBgColor = gocui.ColorWhite
+		v.SelFgColor = gocui.ColorBlack | gocui.AttrBold
+		v.SetOrigin(0, 0)
+		v.SetCursor(0, 0)
+	}
+
+	// set graph panel
+	if v, err := g.SetView(StatsGraphPanel, s.x, graphY, s.w, s.h); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Wrap = false
+		v.Frame = true
+		v.FgColor = gocui.ColorCyan
+	}
+
+	s.SetKeyBinding()
+	s.SwitchPanel(s.name)
+
+	return s.Stream(g)
+}
+
+func (s *StatsList) SetKeyBinding() {
+	if err := s.SetKeybinding(s.name, 'j', gocui.ModNone, s.moveCursor(CursorDown)); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, 'k', gocui.ModNone, s.moveCursor(CursorUp)); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, gocui.KeyCtrlR, gocui.ModNone, s.Refresh); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, gocui.KeyEsc, gocui.ModNone, s.ClosePanel); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, 'q', gocui.ModNone, s.ClosePanel); err != nil {
+		panic(err)
+	}
+	if err := s.SetKeybinding(s.name, gocui.KeyCtrlQ, gocui.ModNone, s.quit); err != nil {
+		panic(err)
+	}
+}
+
+func (s *StatsList) moveCursor(f func(g *gocui.Gui, v *gocui.View) error) func(g *gocui.Gui, v *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		if err := f(g, v); err != nil {
+			return err
+		}
+
+		if selected := s.selected(); selected != nil {
+			s.selectID = selected.ID
+		}
+
+		s.DisplayGraph(g)
+		return nil
+	}
+}
+
+// Refresh restarts streaming stats of running containers.
+func (s *StatsList) Refresh(g *gocui.Gui, v *gocui.View) error {
+	if err := s.Stream(g); err != nil {
+		s.ClosePanel(g, v)
+		s.ErrMessage(err.Error(), s.NextPanel)
+	}
+
+	return nil
+}
+
+// Stream starts streaming stats of the running containers.
+func (s *StatsList) Stream(g *gocui.Gui) error {
+	s.Stop()
+
+	containers, err := s.Docker.ListContainers(docker.ListContainersOptions{})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.Stats = make([]*ContainerStats, 0)
+
+	for _, c := range containers {
+		stats := &ContainerStats{
+			ID:   c.ID[:12],
+			Name: c.Names[0][1:],
+		}
+
+		s.Stats = append(s.Stats, stats)
+
+		if _, ok := s.history[stats.ID]; !ok {
+			s.history[stats.ID] = &statsHistory{}
+		}
+
+		go s.stream(ctx, stats)
+	}
+
+	sort.Slice(s.Stats, func(i, j int) bool {
+		return s.Stats[i].Name < s.Stats[j].Name
+	})
+
+	s.Display(g)
+
+	// move the cursor to the container selected in the container list
+	v, err := g.View(s.name)
+	if err != nil {
+		return err
+	}
+
+	for i, stats := range s.Stats {
+		if stats.ID == s.selectID {
+			SelectLine(v, i)
+		}
+	}
+
+	s.DisplayGraph(g)
+
+	return nil
+}
+
+func (s *StatsList) stream(ctx context.Context, stats *ContainerStats) {
+	ch := make(chan *docker.Stats)
+
+	go s.Docker.Stats(docker.StatsOptions{
+		ID:      stats.ID,
+		Stats:   ch,
+		Stream:  true,
+		Context: ctx,
+	})
+
+	for stat := range ch {
+		stat := stat
+		s.Update(func(g *gocui.Gui) error {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			cpu := CalculateCPUPercent(stat)
+			usage := CalculateMemoryUsage(stat)
+
+			var memory float64
+			if limit := stat.MemoryStats.Limit; limit != 0 {
+				memory = float64(usage) / float64(limit) * 100
+			}
+
+			var rx, tx uint64
+			for _, network := range stat.Networks {
+				rx += network.RxBytes
+				tx += network.TxBytes
+			}
+
+			var read, write uint64
+			for _, entry := range stat.BlkioStats.IOServiceBytesRecursive {
+				switch strings.ToLower(entry.Op) {
+				case "read":
+					read += entry.Value
+				case "write":
+					write += entry.Value
+				}
+			}
+
+			stats.CPU = fmt.Sprintf("%.2f%%", cpu)
+			stats.Memory = fmt.Sprintf("%s / %s", ParseBytesToString(usage), ParseBytesToString(stat.MemoryStats.Limit))
+			stats.MemoryPercent = fmt.Sprintf("%.2f%%", memory)
+			stats.NetIO = fmt.Sprintf("%s / %s", ParseBytesToString(rx), ParseBytesToString(tx))
+			stats.BlockIO = fmt.Sprintf("%s / %s", ParseBytesToString(read), ParseBytesToString(write))
+
+			s.history[stats.ID].add(cpu, memory)
+
+			s.Display(g)
+			s.DisplayGraph(g)
+			return nil
+		})
+	}
+}
+
+// Stop stops streaming stats.
+func (s *StatsList) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+}
+
+func (s *StatsList) selected() *ContainerStats {
+	v, err := s.View(s.name)
+	if err != nil {
+		return nil
+	}
+
+	_, cy := v.Cursor()
+	_, oy := v.Origin()
+
+	index := oy + cy
+	if index >= len(s.Stats) {
+		return nil
+	}
+
+	return s.Stats[index]
+}
+
+func (s *StatsList) Display(g *gocui.Gui) {
+	v, err := g.View(s.name)
+	if err != nil {
+		return
+	}
+
+	v.Clear()
+	for _, stats := range s.Stats {
+		common.OutputFormatedLine(v, stats)
+	}
+}
+
+func (s *StatsList) DisplayGraph(g *gocui.Gui) {
+	v, err := g.View(StatsGraphPanel)
+	if err != nil {
+		return
+	}
+
+	v.Clear()
+
+	stats := s.selected()
+	if stats == nil {
+		v.Title = v.Name()
+		return
+	}
+
+	v.Title = fmt.Sprintf("%s %s", v.Name(), stats.Name)
+
+	history := s.history[stats.ID]
+	if len(history.cpu) == 0 {
+		return
+	}
+
+	maxX, _ := v.Size()
+	width := maxX - 16
+
+	fmt.Fprintf(v, "CPU %10s  %s\n", stats.CPU, Sparkline(history.cpu, 0, width))
+	fmt.Fprintf(v, "MEM %10s  %s\n", stats.MemoryPercent, Sparkline(history.memory, 100, width))
+}
+
+func (s *StatsList) ClosePanel(g *gocui.Gui, v *gocui.View) error {
+	s.Stop()
+
+	s.DeleteKeybindings(s.name)
+
+	// the panel is closed on the error while setting up, so the views may not exist
+	for _, name := range []string{s.name, StatsHeaderPanel, StatsGraphPanel} {
+		if err := s.DeleteView(name); err != nil && err != gocui.ErrUnknownView {
+			panic(err)
+		}
+	}
+
+	s.SwitchPanel(s.NextPanel)
+
+	return nil
+}
+
+// CalculateCPUPercent calculates the cpu usage from the delta of the previous stats like `docker stats`.
+func CalculateCPUPercent(stats *docker.Stats) float64 {
+	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(stats.CPUStats.SystemCPUUsage) - float64(stats.PreCPUStats.SystemCPUUsage)
+
+	cpus := float64(stats.CPUStats.OnlineCPUs)
+	if cpus == 0 {
+		cpus = float64(len(stats.CPUStats.CPUUsage.PercpuUsage))
+	}
+
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+
+	return cpuDelta / systemDelta * cpus * 100
+}
+
+// CalculateMemoryUsage returns the memory usage excluding the page cache like `docker stats`.
+func CalculateMemoryUsage(stats *docker.Stats) uint64 {
+	usage := stats.MemoryStats.Usage
+
+	// cgroup v1
+	if cache := stats.MemoryStats.Stats.TotalInactiveFile; cache != 0 && cache < usage {
+		return usage - cache
+	}
+
+	// cgroup v2
+	if cache := stats.MemoryStats.Stats.InactiveFile; cache < usage {
+		return usage - cache
+	}
+
+	return usage
+}
+
+// Sparkline draws the last width values. If max is 0, the values are scaled by the maximum of them.
+func Sparkline(values []float64, max float64, width int) string {
+	if width < 1 {
+		return ""
+	}
+
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	if max == 0 {
+		for _, value := range values {
+			if value > max {
+				max = value
+			}
+		}
+	}
+
+	if max == 0 {
+		max = 1
+	}
+
+	var line []rune
+	for _, value := range values {
+		index := int(value / max * float64(len(sparks)-1))
+		if index < 0 {
+			index = 0
+		}
+		if index >= len(sparks) {
+			index = len(sparks) - 1
+		}
+
+		line = append(line, sparks[index])
+	}
+
+	return string(line)
+}
@@ -0,0 +1,218 @@
+package panel
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jroimartin/gocui"
+	"github.com/skanehira/docui/common"
+	"github.com/skanehira/docui/docker"
+)
+
+type TaskList struct {
+	*Gui
+	Position
+	name    string
+	prev    string
+	title   string
+	filters map[string][]string
+	Tasks   []*Task
+}
+
+type Task struct {
+	ID      string `tag:"ID" len:"min:0.1 max:0.1"`
+	Name    string `tag:"NAME" len:"min:0.1 max:0.2"`
+	Node    string `tag:"NODE" len:"min:0.1 max:0.1"`
+	Desired string `tag:"DESIRED" len:"min:0.1 max:0.1"`
+	State   string `tag:"STATE" len:"min:0.1 max:0.2"`
+	Error   string `tag:"ERROR" len:"min:0.1 max:0.3"`
+}
+
+// NewTaskList returns the task list of the service or the node filtered by filters.
+func NewTaskList(gui *Gui, name string, x, y, w, h int, title string, filters map[string][]string) *TaskList {
+	return &TaskList{
+		Gui:      gui,
+		name:     name,
+		Position: Position{x, y, w, h},
+		title:    title,
+		filters:  filters,
+	}
+}
+
+func (t *TaskList) Name() string {
+	return t.name
+}
+
+func (t *TaskList) SetView(g *gocui.Gui) error {
+	tasks, err := t.tasks()
+	if err != nil {
+		return err
+	}
+
+	t.Tasks = tasks
+	t.prev = t.NextPanel
+
+	// set header panel
+	if v, err := g.SetView(TaskListHeaderPanel, t.x, t.y, t.w, t.h); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Wrap = true
+		v.Frame = true
+		v.Title = fmt.Sprintf("%s %s", v.Name(), t.title)
+		v.FgColor = gocui.AttrBold | gocui.ColorWhite
+		common.OutputFormatedHeader(v, &Task{})
+	}
+
+	// set scroll panel
+	v, err := g.SetView(t.name, t.x, t.y+1, t.w, t.h)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Wrap = true
+		v.FgColor = gocui.ColorGreen
+		v.SelBgColor = gocui.ColorWhite
+		v.SelFgColor = gocui.ColorBlack | gocui.AttrBold
+		v.SetOrigin(0, 0)
+		v.SetCursor(0, 0)
+	}
+
+	t.GetTaskList(v)
+	t.SetKeyBinding()
+	t.SwitchPanel(t.name)
+
+	return nil
+}
+
+func (t *TaskList) SetKeyBinding() {
+	if err := t.SetKeybinding(t.name, 'j', gocui.ModNone, CursorDown); err != nil {
+		panic(err)
+	}
+	if err := t.SetKeybinding(t.name, 'k', gocui.ModNone, CursorUp); err != nil {
+		panic(err)
+	}
+	if err := t.SetKeybinding(t.name, gocui.KeyCtrlR, gocui.ModNone, t.Refresh); err != nil {
+		panic(err)
+	}
+	if err := t.SetKeybinding(t.name, gocui.KeyEsc, gocui.ModNone, t.CloseTaskListPanel); err != nil {
+		panic(err)
+	}
+	if err := t.SetKeybinding(t.name, 'q', gocui.ModNone, t.CloseTaskListPanel); err != nil {
+		panic(err)
+	}
+	if err := t.SetKeybinding(t.name, gocui.KeyCtrlQ, gocui.ModNone, t.quit); err != nil {
+		panic(err)
+	}
+}
+
+// tasks returns the tasks with the names of the services and the host names of the nodes,
+// sorted by the service, the slot and the newest first like `docker service ps`.
+func (t *TaskList) tasks() ([]*Task, error) {
+	tasks, err := t.Docker.Tasks(t.filters)
+	if err != nil {
+		return nil, err
+	}
+
+	services := make(map[string]string)
+	if list, err := t.Docker.Services(); err == nil {
+		for _, service := range list {
+			services[service.ID] = service.Spec.Name
+		}
+	}
+
+	nodes := make(map[string]string)
+	if list, err := t.Docker.Nodes(); err == nil {
+		for _, node := range list {
+			nodes[node.ID] = node.Description.Hostname
+		}
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		if si, sj := services[tasks[i].ServiceID], services[tasks[j].ServiceID]; si != sj {
+			return si < sj
+		}
+		if tasks[i].Slot != tasks[j].Slot {
+			return tasks[i].Slot < tasks[j].Slot
+		}
+		return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
+	})
+
+	var rows []*Task
+	for _, task := range tasks {
+		node, ok := nodes[task.NodeID]
+		if !ok {
+			node = task.NodeID
+		}
+
+		service, ok := services[task.ServiceID]
+		if !ok {
+			service = task.ServiceID
+		}
+
+		rows = append(rows, &Task{
+			ID:      task.ID,
+			Name:    taskName(service, task),
+			Node:    node,
+			Desired: task.DesiredState,
+			State:   fmt.Sprintf("%s %s", task.Status.State, ParseDateToString(task.Status.Timestamp.Unix())),
+			Error:   task.Status.Err,
+		})
+	}
+
+	return rows, nil
+}
+
+// taskName returns the name of the task like `service.1`, or `service.node` in global mode.
+func taskName(service string, task *docker.Task) string {
+	if task.Slot != 0 {
+		return fmt.Sprintf("%s.%d", service, task.Slot)
+	}
+
+	return fmt.Sprintf("%s.%s", service, task.NodeID)
+}
+
+func (t *TaskList) Refresh(g *gocui.Gui, v *gocui.View) error {
+	tasks, err := t.tasks()
+	if err != nil {
+		t.ErrMessage(err.Error(), t.name)
+		return nil
+	}
+
+	t.Tasks = tasks
+
+	v, err = g.View(t.name)
+	if err != nil {
+		return err
+	}
+
+	t.GetTaskList(v)
+
+	return nil
+}
+
+func (t *TaskList) GetTaskList(v *gocui.View) {
+	v.Clear()
+
+	for _, task := range t.Tasks {
+		common.OutputFormatedLine(v, task)
+	}
+}
+
+func (t *TaskList) CloseTaskListPanel(g *gocui.Gui, v *gocui.View) error {
+	t.DeleteKeybindings(t.name)
+
+	for _, name := range []string{t.name, TaskListHeaderPanel} {
+		if err := t.DeleteView(name); err != nil {
+			return err
+		}
+	}
+
+	t.NextPanel = t.prev
+	t.SwitchPanel(t.NextPanel)
+
+	return nil
+}
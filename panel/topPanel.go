@@ -0,0 +1,321 @@
+package panel
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/jroimartin/gocui"
+	"github.com/skanehira/docui/common"
+)
+
+const (
+	// interval to refresh the process list
+	topRefreshInterval = 2 * time.Second
+
+	// ps options to get the columns of the process list
+	topPsArgs = "-eo pid,user,pcpu,pmem,rss,args"
+)
+
+type TopList struct {
+	*Gui
+	Position
+	name           string
+	prev           string
+	container      string
+	containerName  string
+	Processes      []*Process
+	cancel         context.CancelFunc
+	Data           map[string]interface{}
+	ClosePanelName string
+	Items          Items
+}
+
+type Process struct {
+	PID     string `tag:"PID" len:"min:0.1 max:0.1"`
+	User    string `tag:"USER" len:"min:0.1 max:0.1"`
+	CPU     string `tag:"CPU %" len:"min:0.1 max:0.1"`
+	Memory  string `tag:"MEM %" len:"min:0.1 max:0.1"`
+	RSS     string `tag:"RSS" len:"min:0.1 max:0.1"`
+	Command string `tag:"COMMAND" len:"min:0.1 max:0.5"`
+}
+
+func NewTopList(gui *Gui, name string, x, y, w, h int, container, containerName string) *TopList {
+	return &TopList{
+		Gui:           gui,
+		name:          name,
+		Position:      Position{x, y, w, h},
+		container:     container,
+		containerName: containerName,
+		Data:          make(map[string]interface{}),
+		Items:         Items{},
+	}
+}
+
+func (t *TopList) Name() string {
+	return t.name
+}
+
+func (t *TopList) SetView(g *gocui.Gui) error {
+	processes, err := t.top()
+	if err != nil {
+		return err
+	}
+
+	t.Processes = processes
+	t.prev = t.NextPanel
+
+	// set header panel
+	if v, err := g.SetView(TopHeaderPanel, t.x, t.y, t.w, t.h); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Wrap = true
+		v.Frame = true
+		v.Title = fmt.Sprintf("%s %s", v.Name(), t.containerName)
+		v.FgColor = gocui.AttrBold | gocui.ColorWhite
+		common.OutputFormatedHeader(v, &Process{})
+	}
+
+	// set scroll panel
+	v, err := g.SetView(t.name, t.x, t.y+1, t.w, t.h)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Wrap = true
+		v.FgColor = gocui.ColorGreen
+		v.SelBgColor = gocui.ColorWhite
+		v.SelFgColor = gocui.ColorBlack | gocui.AttrBold
+		v.SetOrigin(0, 0)
+		v.SetCursor(0, 0)
+	}
+
+	t.GetProcessList(v)
+	t.SetKeyBinding()
+	t.SwitchPanel(t.name)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	go t.watch(ctx)
+
+	return nil
+}
+
+func (t *TopList) SetKeyBinding() {
+	if err := t.SetKeybinding(t.name, 'j', gocui.ModNone, CursorDown); err != nil {
+		panic(err)
+	}
+	if err := t.SetKeybinding(t.name, 'k', gocui.ModNone, CursorUp); err != nil {
+		panic(err)
+	}
+	if err := t.SetKeybinding(t.name, 'K', gocui.ModNone, t.KillContainerPanel); err != nil {
+		panic(err)
+	}
+	if err := t.SetKeybinding(t.name, gocui.KeyCtrlR, gocui.ModNone, t.Refresh); err != nil {
+		panic(err)
+	}
+	if err := t.SetKeybinding(t.name, gocui.KeyEsc, gocui.ModNone, t.CloseTopPanel); err != nil {
+		panic(err)
+	}
+	if err := t.SetKeybinding(t.name, 'q', gocui.ModNone, t.CloseTopPanel); err != nil {
+		panic(err)
+	}
+	if err := t.SetKeybinding(t.name, gocui.KeyCtrlQ, gocui.ModNone, t.quit); err != nil {
+		panic(err)
+	}
+}
+
+// top returns the processes in the container.
+// The default options of ps are used if the host does not support topPsArgs.
+func (t *TopList) top() ([]*Process, error) {
+	result, err := t.Docker.TopContainer(t.container, topPsArgs)
+	if err != nil {
+		if result, err = t.Docker.TopContainer(t.container, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewProcesses(result), nil
+}
+
+// watch refreshes the process list periodically until ctx is canceled.
+func (t *TopList) watch(ctx context.Context) {
+	ticker := time.NewTicker(topRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		processes, err := t.top()
+
+		t.Update(func(g *gocui.Gui) error {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return t.display(g, processes, err)
+		})
+	}
+}
+
+func (t *TopList) Refresh(g *gocui.Gui, v *gocui.View) error {
+	processes, err := t.top()
+	return t.display(g, processes, err)
+}
+
+// display shows the processes, or the error in the title when the container is not running.
+func (t *TopList) display(g *gocui.Gui, processes []*Process, err error) error {
+	header, herr := g.View(TopHeaderPanel)
+	if herr != nil {
+		return nil
+	}
+
+	header.Title = fmt.Sprintf("%s %s", header.Name(), t.containerName)
+	if err != nil {
+		header.Title += fmt.Sprintf(" (%s)", err)
+		return nil
+	}
+
+	t.Processes = processes
+
+	v, err := g.View(t.name)
+	if err != nil {
+		return nil
+	}
+
+	t.GetProcessList(v)
+
+	return nil
+}
+
+func (t *TopList) GetProcessList(v *gocui.View) {
+	v.Clear()
+
+	for _, process := range t.Processes {
+		common.OutputFormatedLine(v, process)
+	}
+}
+
+// NewProcesses converts the result of ps to the rows by the titles,
+// because the columns depend on the options of ps.
+func NewProcesses(result docker.TopResult) []*Process {
+	columns := make(map[string]int)
+	for i, title := range result.Titles {
+		columns[strings.ToUpper(title)] = i
+	}
+
+	column := func(process []string, titles ...string) string {
+		for _, title := range titles {
+			if i, ok := columns[title]; ok && i < len(process) {
+				return process[i]
+			}
+		}
+
+		return ""
+	}
+
+	var processes []*Process
+	for _, p := range result.Processes {
+		process := &Process{
+			PID:     column(p, "PID"),
+			User:    column(p, "USER", "UID"),
+			CPU:     column(p, "%CPU", "C"),
+			Memory:  column(p, "%MEM"),
+			Command: column(p, "COMMAND", "CMD", "ARGS"),
+		}
+
+		// RSS is in KiB
+		if rss, err := strconv.ParseInt(column(p, "RSS", "RSZ"), 10, 64); err == nil {
+			process.RSS = ParseSizeToString(rss * 1024)
+		}
+
+		processes = append(processes, process)
+	}
+
+	return processes
+}
+
+func (t *TopList) KillContainerPanel(g *gocui.Gui, v *gocui.View) error {
+	t.NextPanel = t.name
+
+	t.Data = map[string]interface{}{
+		"Container": t.containerName,
+		"Signal":    "SIGKILL",
+	}
+
+	maxX, maxY := t.Size()
+	x := maxX / 8
+	y := maxY / 3
+	w := maxX - x
+	h := y + 6
+
+	t.ClosePanelName = KillContainerPanel
+	t.Items = NewItems([]string{"Container", "Signal"}, x, y, w, h, 12)
+
+	handlers := Handlers{
+		gocui.KeyEnter: t.KillContainer,
+	}
+
+	NewInput(t.Gui, KillContainerPanel, x, y, w, h, t.Items, t.Data, handlers)
+	return nil
+}
+
+func (t *TopList) KillContainer(g *gocui.Gui, v *gocui.View) error {
+	data, err := t.GetItemsToMap(t.Items)
+	if err != nil {
+		t.ClosePanel(g, v)
+		t.ErrMessage(err.Error(), t.NextPanel)
+		return nil
+	}
+
+	if data["Signal"] == "" {
+		return nil
+	}
+
+	g.Update(func(g *gocui.Gui) error {
+		t.ClosePanel(g, v)
+
+		if err := t.Docker.KillContainerWithSignal(t.container, data["Signal"]); err != nil {
+			t.ErrMessage(err.Error(), t.NextPanel)
+			return nil
+		}
+
+		return t.Refresh(g, v)
+	})
+
+	return nil
+}
+
+func (t *TopList) ClosePanel(g *gocui.Gui, v *gocui.View) error {
+	return t.Panels[t.ClosePanelName].(*Input).ClosePanel(g, v)
+}
+
+func (t *TopList) CloseTopPanel(g *gocui.Gui, v *gocui.View) error {
+	if t.cancel != nil {
+		t.cancel()
+	}
+
+	t.DeleteKeybindings(t.name)
+
+	for _, name := range []string{t.name, TopHeaderPanel} {
+		if err := t.DeleteView(name); err != nil {
+			return err
+		}
+	}
+
+	t.NextPanel = t.prev
+	t.SwitchPanel(t.NextPanel)
+
+	return nil
+}
@@ -18,6 +18,7 @@ type VolumeList struct {
 	Items          Items
 	ClosePanelName string
 	filter         string
+	marked         Marks
 }
 
 type Volume struct {
@@ -36,6 +37,7 @@ func NewVolumeList(gui *Gui, name string, x, y, w, h int) *VolumeList {
 		Position: Position{x, y, w, h},
 		Data:     make(map[string]interface{}),
 		Items:    Items{},
+		marked:   make(Marks),
 	}
 }
 
@@ -94,20 +96,12 @@ func (vl *VolumeList) SetView(g *gocui.Gui) error {
 		v.SelFgColor = gocui.ColorBlack | gocui.AttrBold
 		v.SetOrigin(0, 0)
 		v.SetCursor(0, 0)
+
+		vl.GetVolumeList(v)
 	}
 
 	vl.SetKeyBinding()
 
-	//monitoring volume interval 5s
-	go func() {
-		for {
-			vl.Update(func(g *gocui.Gui) error {
-				vl.Refresh(g, v)
-				return nil
-			})
-			time.Sleep(5 * time.Second)
-		}
-	}()
 	return nil
 }
 
@@ -123,6 +117,12 @@ func (vl *VolumeList) SetKeyBinding() {
 	if err := vl.SetKeybinding(vl.name, 'c', gocui.ModNone, vl.CreateVolumePanel); err != nil {
 		panic(err)
 	}
+	if err := vl.SetKeybinding(vl.name, gocui.KeySpace, gocui.ModNone, vl.Mark); err != nil {
+		panic(err)
+	}
+	if err := vl.SetKeybinding(vl.name, 'a', gocui.ModNone, vl.MarkAll); err != nil {
+		panic(err)
+	}
 	if err := vl.SetKeybinding(vl.name, 'd', gocui.ModNone, vl.RemoveVolume); err != nil {
 		panic(err)
 	}
@@ -183,7 +183,10 @@ func (vl *VolumeList) GetVolumeList(v *gocui.View) {
 	var keys []string
 	tmpMap := make(map[string]*Volume)
 
+	var names []string
 	for _, volume := range vl.Docker.Volumes() {
+		names = append(names, volume.Name)
+
 		if vl.filter != "" {
 			if strings.Index(strings.ToLower(volume.Name), strings.ToLower(vl.filter)) == -1 {
 				continue
@@ -201,10 +204,62 @@ func (vl *VolumeList) GetVolumeList(v *gocui.View) {
 	}
 
 	for _, key := range common.SortKeys(keys) {
-		common.OutputFormatedLine(v, tmpMap[key])
 		vl.Volumes = append(vl.Volumes, tmpMap[key])
 	}
 
+	vl.marked.Retain(names)
+	vl.DisplayVolumeList(v)
+}
+
+func (vl *VolumeList) DisplayVolumeList(v *gocui.View) {
+	v.Clear()
+
+	for _, volume := range vl.Volumes {
+		OutputMarkedLine(v, vl.marked[volume.Name], volume)
+	}
+}
+
+func (vl *VolumeList) names() []string {
+	var names []string
+	for _, volume := range vl.Volumes {
+		names = append(names, volume.Name)
+	}
+
+	return names
+}
+
+// targets returns the names of the marked volumes, or the selected volume if nothing is marked.
+func (vl *VolumeList) targets() ([]string, error) {
+	if marked := vl.marked.Filter(vl.names()); len(marked) > 0 {
+		return marked, nil
+	}
+
+	volume, err := vl.selected()
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{volume.Name}, nil
+}
+
+func (vl *VolumeList) Mark(g *gocui.Gui, v *gocui.View) error {
+	volume, err := vl.selected()
+	if err != nil {
+		return nil
+	}
+
+	vl.marked.Toggle(volume.Name)
+	vl.DisplayVolumeList(v)
+
+	return CursorDown(g, v)
+}
+
+// MarkAll marks all volumes shown with the filter, or unmarks them if all of them are marked.
+func (vl *VolumeList) MarkAll(g *gocui.Gui, v *gocui.View) error {
+	vl.marked.ToggleAll(vl.names())
+	vl.DisplayVolumeList(v)
+
+	return nil
 }
 
 func (vl *VolumeList) CreateVolumePanel(g *gocui.Gui, v *gocui.View) error {
@@ -266,23 +321,22 @@ func (vl *VolumeList) CreateVolume(g *gocui.Gui, v *gocui.View) error {
 func (vl *VolumeList) RemoveVolume(g *gocui.Gui, v *gocui.View) error {
 	vl.NextPanel = vl.name
 
-	selected, err := vl.selected()
+	names, err := vl.targets()
 	if err != nil {
 		vl.ErrMessage(err.Error(), vl.NextPanel)
 		return nil
 	}
 
-	_, err = vl.Docker.InspectVolume(selected.Name)
-	if err != nil {
-		vl.ErrMessage(err.Error(), vl.NextPanel)
-		return nil
-	}
-
-	vl.ConfirmMessage("Are you sure you want to remove this volume? (y/n)", func(g *gocui.Gui, v *gocui.View) error {
+	vl.ConfirmMessage(fmt.Sprintf("Are you sure you want to remove %s? (y/n)", confirmTarget(len(names), "volume")), func(g *gocui.Gui, v *gocui.View) error {
 		defer vl.Refresh(g, v)
 		defer vl.CloseConfirmMessage(g, v)
 
-		if err := vl.Docker.RemoveVolumeWithName(selected.Name); err != nil {
+		err := BulkDo(names, idName, vl.Docker.RemoveVolumeWithName)
+		for _, name := range names {
+			delete(vl.marked, name)
+		}
+
+		if err != nil {
 			vl.ErrMessage(err.Error(), vl.NextPanel)
 			return nil
 		}
@@ -301,17 +355,7 @@ func (vl *VolumeList) PruneVolumes(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
-	vl.ConfirmMessage("Are you sure you want to remove unused volumes? (y/n)", func(g *gocui.Gui, v *gocui.View) error {
-		defer vl.Refresh(g, v)
-		defer vl.CloseConfirmMessage(g, v)
-
-		if err := vl.Docker.PruneVolumes(); err != nil {
-			vl.ErrMessage(err.Error(), vl.NextPanel)
-			return nil
-		}
-
-		return nil
-	})
+	vl.ConfirmPrune("unused volumes", "volumes", vl.Docker.PruneUnusedVolumes)
 
 	return nil
 }